@@ -0,0 +1,10 @@
+package fsrs
+
+import "time"
+
+// Algorithm is the review operation of Scheduler and MultiScheduler, for
+// queue and persistence code that should not depend on which one it gets,
+// or on FSRS at all in its tests.
+type Algorithm interface {
+	Review(card Card, rating Rating, elapsed time.Duration, opts ...ReviewOption) (ReviewResult, error)
+}
@@ -0,0 +1,22 @@
+package fsrs
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSchedulersImplementAlgorithm(t *testing.T) {
+	scheduler := createDefaultScheduler()
+	algorithms := []Algorithm{scheduler, NewMultiScheduler(scheduler, nil)}
+
+	expected, _ := scheduler.Review(NewCard(1), Good, 0)
+	for _, algorithm := range algorithms {
+		result, err := algorithm.Review(NewCard(1), Good, 0)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if result.Card.State != expected.Card.State || result.Card.Interval != 10*time.Minute {
+			t.Errorf("Expected %+v, but got %+v", expected.Card, result.Card)
+		}
+	}
+}
@@ -0,0 +1,279 @@
+package fsrs
+
+import (
+	"math"
+	"slices"
+	"time"
+)
+
+func (s *Scheduler) PostponementCost(card Card, lastReview time.Time, postponeBy time.Duration) float64 {
+	if card.State == New || card.Stability <= 0 {
+		return 0
+	}
+	due := lastReview.Add(card.Interval)
+	onTime := s.retrievability(card.Stability, due.Sub(lastReview))
+	postponed := s.retrievability(card.Stability, due.Add(postponeBy).Sub(lastReview))
+	return postponed - onTime
+}
+
+func (s *Scheduler) AdvanceBenefit(card Card, lastReview time.Time, advanceBy time.Duration) float64 {
+	if card.State == New {
+		return 0
+	}
+	due := lastReview.Add(card.Interval)
+	early := due.Add(-advanceBy)
+	if early.Before(lastReview) {
+		early = lastReview
+	}
+	onTime := s.calculateInitialReviewedCard(card, Good, due.Sub(lastReview))
+	advanced := s.calculateInitialReviewedCard(card, Good, early.Sub(lastReview))
+	return advanced.Stability - onTime.Stability
+}
+
+// IntervalRatio returns how many times longer intervals scheduled at
+// retentionA are than those scheduled at retentionB, for the forgetting
+// curve with the given decay (w[20]).
+func IntervalRatio(retentionA, retentionB, decay float64) float64 {
+	return (math.Pow(retentionA, -1.0/decay) - 1.0) / (math.Pow(retentionB, -1.0/decay) - 1.0)
+}
+
+func (s *Scheduler) IntervalRatio(retentionA, retentionB float64) float64 {
+	return IntervalRatio(retentionA, retentionB, -s.decay)
+}
+
+// ApproxInterval returns the interval the card's stability implies at the
+// retention for its difficulty.
+func (c Card) ApproxInterval(scheduler *Scheduler) time.Duration {
+	return scheduler.intervalForRetention(c.Stability, scheduler.desiredRetention(c.Difficulty))
+}
+
+// StabilityForInterval returns the stability for which a card of the given
+// difficulty is scheduled interval apart, the inverse of ApproxInterval.
+func (s *Scheduler) StabilityForInterval(interval time.Duration, difficulty float64) float64 {
+	return s.stabilityForInterval(interval, s.desiredRetention(difficulty))
+}
+
+func (s *Scheduler) stabilityForInterval(interval time.Duration, retention float64) float64 {
+	intervalDays := interval.Hours() / dayDuration.Hours() / s.intervalScale()
+	return clampStability(intervalDays * s.factor / (math.Pow(retention, 1.0/s.decay) - 1.0))
+}
+
+// PriorityScore returns (1 - R) * S'/S, where R is the current
+// retrievability and S'/S the stability multiplier of a Good review now.
+func (s *Scheduler) PriorityScore(card Card, elapsed time.Duration) float64 {
+	if card.State == New || card.Stability <= 0 {
+		return 0
+	}
+	urgency := 1.0 - s.retrievability(card.Stability, elapsed)
+	reviewed := s.calculateInitialReviewedCard(card, Good, elapsed)
+	return urgency * reviewed.Stability / card.Stability
+}
+
+func (s *Scheduler) UsingDefaultParameters() bool {
+	defaults, _ := checkAndFillParameters(DefaultSchedulerConfig().Parameters)
+	if len(defaults) != len(s.w) {
+		return false
+	}
+	for i := range defaults {
+		if math.Abs(defaults[i]-s.w[i]) > 1e-9 {
+			return false
+		}
+	}
+	return true
+}
+
+// IntervalAtAge returns the interval scheduled for the card's current
+// stability. FSRS intervals depend on stability alone, so ageDays does not
+// affect the result.
+func (s *Scheduler) IntervalAtAge(card Card, ageDays int) time.Duration {
+	return s.CalculateNextReviewInterval(card.Stability)
+}
+
+// NormalizeCard clamps the memory state into its valid range and, for
+// Review cards, recomputes Interval from stability.
+func (s *Scheduler) NormalizeCard(card Card) Card {
+	card.Difficulty = clampDifficulty(card.Difficulty)
+	card.Stability = clampStability(card.Stability)
+	if card.State == Review {
+		card.Interval = s.intervalForRetention(card.Stability, s.desiredRetention(card.Difficulty))
+	}
+	return card
+}
+
+// NormalizeCardKeepDue is NormalizeCard for callers that must keep the
+// stored Interval, and with it Due; for Review cards it adjusts stability
+// to match the interval instead.
+func (s *Scheduler) NormalizeCardKeepDue(card Card) Card {
+	card.Difficulty = clampDifficulty(card.Difficulty)
+	card.Stability = clampStability(card.Stability)
+	if card.State == Review {
+		card.Stability = s.stabilityForInterval(card.Interval, s.desiredRetention(card.Difficulty))
+	}
+	return card
+}
+
+// ScheduleDivergence returns the mean absolute difference in days between
+// the intervals a and b schedule for each stability.
+func ScheduleDivergence(a, b *Scheduler, stabilities []float64) float64 {
+	if len(stabilities) == 0 {
+		return 0
+	}
+	total := 0.0
+	for _, stability := range stabilities {
+		difference := a.CalculateNextReviewInterval(stability) - b.CalculateNextReviewInterval(stability)
+		total += math.Abs(difference.Hours() / dayDuration.Hours())
+	}
+	return total / float64(len(stabilities))
+}
+
+const memoryStrengthStabilityScale = 30.0
+
+// MemoryStrength returns R * (1 - exp(-S/30)), where R is the current
+// retrievability and S the stability in days. The first factor reflects
+// how well the card is remembered now and the second how durable that
+// memory is, so only recently reviewed cards with months of stability
+// approach 1. New cards have strength 0.
+func (s *Scheduler) MemoryStrength(card Card, now time.Time) float64 {
+	if card.State == New || card.Stability <= 0 {
+		return 0
+	}
+	durability := 1.0 - math.Exp(-card.Stability/memoryStrengthStabilityScale)
+	return s.cardRetrievability(card, now) * durability
+}
+
+// TypicalGrowthFactor returns the median interval multiplier of an on-time
+// Good review, across stabilities from 1 to 365 days at medium difficulty.
+func (s *Scheduler) TypicalGrowthFactor() float64 {
+	const samples = 21
+	const difficulty = 5.0
+	retention := s.desiredRetention(difficulty)
+
+	factors := make([]float64, samples)
+	for i := range factors {
+		stability := math.Pow(365, float64(i)/float64(samples-1))
+		intervalDays := stability / s.factor * (math.Pow(retention, 1.0/s.decay) - 1.0)
+		card := Card{State: Review, Stability: stability, Difficulty: difficulty}
+		elapsed := time.Duration(intervalDays * float64(dayDuration))
+		factors[i] = s.calculateInitialReviewedCard(card, Good, elapsed).Stability / stability
+	}
+
+	slices.Sort(factors)
+	return factors[samples/2]
+}
+
+// SurvivesGap reports whether the card's retrievability stays at or above
+// minRetention through a gap starting at now. New cards never survive.
+func (s *Scheduler) SurvivesGap(card Card, lastReview, now time.Time, gap time.Duration, minRetention float64) bool {
+	if card.State == New || card.Stability <= 0 {
+		return false
+	}
+	return s.retrievability(card.Stability, now.Sub(lastReview)+gap) >= minRetention
+}
+
+// EffectiveParameters returns the 21 weights the scheduler actually uses,
+// with w[20] replaced by OverrideDecay when it is set.
+func (s *Scheduler) EffectiveParameters() []float64 {
+	w := slices.Clone(s.w)
+	w[20] = -s.decay
+	return w
+}
+
+// Decay returns the forgetting curve decay in use and whether it comes from
+// OverrideDecay rather than the parameters.
+func (s *Scheduler) Decay() (decay float64, overridden bool) {
+	return -s.decay, s.config.OverrideDecay != nil
+}
+
+// HalfLife returns the time after a review at which retrievability falls
+// to 0.5, rounded to the minute. Positive stabilities never round below one
+// minute.
+func (s *Scheduler) HalfLife(stability float64) time.Duration {
+	if stability <= 0 {
+		return 0
+	}
+	days := stability / s.factor * (math.Pow(0.5, 1.0/s.decay) - 1.0)
+	return max(time.Duration(days*float64(dayDuration)).Round(time.Minute), time.Minute)
+}
+
+// ThresholdDate returns when the card's retrievability falls to threshold
+// after a review at lastReview.
+func (s *Scheduler) ThresholdDate(card Card, lastReview time.Time, threshold float64) time.Time {
+	days := card.Stability / s.factor * (math.Pow(threshold, 1.0/s.decay) - 1.0)
+	return lastReview.Add(time.Duration(days * float64(dayDuration)))
+}
+
+// DecayWarnings returns the ThresholdDate of each threshold. It returns
+// nil for a New card or when thresholds are not strictly descending
+// within (0, 1).
+func (s *Scheduler) DecayWarnings(card Card, lastReview time.Time, thresholds []float64) []time.Time {
+	if card.State == New || card.Stability <= 0 {
+		return nil
+	}
+	for i, threshold := range thresholds {
+		if !(threshold > 0 && threshold < 1) || (i > 0 && threshold >= thresholds[i-1]) {
+			return nil
+		}
+	}
+
+	warnings := make([]time.Time, len(thresholds))
+	for i, threshold := range thresholds {
+		warnings[i] = s.ThresholdDate(card, lastReview, threshold)
+	}
+	return warnings
+}
+
+// RoundingError returns the exact interval for the stability minus the
+// whole-day interval CalculateNextReviewInterval schedules. Its magnitude
+// never exceeds half a day.
+func (s *Scheduler) RoundingError(stability float64) time.Duration {
+	exact := exactIntervalDays(s.factor, s.config.DesiredRetention, s.decay, s.config.MaximumInterval, s.intervalScale(), stability)
+	return time.Duration(exact*float64(dayDuration)) - s.CalculateNextReviewInterval(stability)
+}
+
+const (
+	RecommendOverdue = "overdue — review now"
+	RecommendDue     = "due today"
+	RecommendNotDue  = "not due — studying now gains little"
+)
+
+// ReviewRecommendation advises whether to review the card now. It is not
+// due before its due date, overdue once that day has passed and its
+// retrievability is below the target, and due today otherwise. New cards
+// are due today.
+func (s *Scheduler) ReviewRecommendation(card Card, lastReview, now time.Time) string {
+	if card.State == New {
+		return RecommendDue
+	}
+	today := startOfDay(now)
+	dueDay := startOfDay(lastReview.Add(card.Interval).In(now.Location()))
+	switch {
+	case dueDay.After(today):
+		return RecommendNotDue
+	case dueDay.Before(today) && s.retrievability(card.Stability, now.Sub(lastReview)) < s.desiredRetention(card.Difficulty):
+		return RecommendOverdue
+	default:
+		return RecommendDue
+	}
+}
+
+// Retrievability returns the predicted probability of recalling the card
+// after elapsed time since its last review, for any elapsed time including
+// under a day. Negative elapsed times count as zero. New cards and cards
+// without stability have nothing to recall and return 0.
+func (s *Scheduler) Retrievability(card Card, elapsed time.Duration) float64 {
+	if card.State == New || card.Stability <= 0 {
+		return 0
+	}
+	return s.retrievability(card.Stability, elapsed)
+}
+
+// GetRetrievability is Retrievability for "due now" queries, where cards
+// that have not been learned yet must never look forgotten. It differs
+// only for New cards and cards without stability, which return 1 rather
+// than 0.
+func (s *Scheduler) GetRetrievability(card Card, elapsed time.Duration) float64 {
+	if card.State == New || card.Stability <= 0 {
+		return 1.0
+	}
+	return s.retrievability(card.Stability, elapsed)
+}
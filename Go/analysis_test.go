@@ -0,0 +1,485 @@
+package fsrs
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestPostponementCost(t *testing.T) {
+	scheduler := createDefaultScheduler()
+	lastReview := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	card := Card{CardID: 1, State: Review, Stability: 5, Difficulty: 5, Interval: 5 * dayDuration}
+
+	short := scheduler.PostponementCost(card, lastReview, 2*dayDuration)
+	long := scheduler.PostponementCost(card, lastReview, 10*dayDuration)
+	if short >= 0 {
+		t.Errorf("Expected negative cost, but got %v", short)
+	}
+	if long >= short {
+		t.Errorf("Expected larger postponement to cost more, but got %v for 2 days and %v for 10 days", short, long)
+	}
+
+	strong := Card{CardID: 2, State: Review, Stability: 100, Difficulty: 5, Interval: 5 * dayDuration}
+	strongCost := scheduler.PostponementCost(strong, lastReview, 10*dayDuration)
+	if strongCost <= long {
+		t.Errorf("Expected high-stability card to cost less, but got %v vs %v", strongCost, long)
+	}
+
+	if cost := scheduler.PostponementCost(NewCard(3), lastReview, 10*dayDuration); cost != 0 {
+		t.Errorf("Expected zero cost for a new card, but got %v", cost)
+	}
+}
+
+func TestAdvanceBenefit(t *testing.T) {
+	scheduler := createDefaultScheduler()
+	lastReview := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	card := Card{CardID: 1, State: Review, Stability: 20, Difficulty: 5, Interval: 20 * dayDuration}
+
+	onTime := scheduler.AdvanceBenefit(card, lastReview, 0)
+	if onTime != 0 {
+		t.Errorf("Expected zero change for an on-time review, but got %v", onTime)
+	}
+
+	previous := onTime
+	for _, days := range []int{2, 5, 10, 15} {
+		change := scheduler.AdvanceBenefit(card, lastReview, time.Duration(days)*dayDuration)
+		if change >= previous {
+			t.Errorf("Expected reviewing %d days early to gain less stability, but got %v after %v", days, change, previous)
+		}
+		previous = change
+	}
+}
+
+func TestIntervalRatio(t *testing.T) {
+	cases := []struct {
+		retentionA, retentionB, decay, expected float64
+	}{
+		{0.87, 0.90, 0.1542, 1.4967202708492016},
+		{0.80, 0.90, 0.1542, 3.3159597862309815},
+		{0.95, 0.90, 0.1542, 0.402558691989992},
+		{0.87, 0.90, 0.5, 1.3692346204393273},
+		{0.90, 0.90, 0.1542, 1.0},
+	}
+
+	for _, c := range cases {
+		actual := IntervalRatio(c.retentionA, c.retentionB, c.decay)
+		if math.Abs(actual-c.expected) > 1e-9 {
+			t.Errorf("Expected ratio %v for %v vs %v, but got %v", c.expected, c.retentionA, c.retentionB, actual)
+		}
+	}
+
+	scheduler := createDefaultScheduler()
+	if actual := scheduler.IntervalRatio(0.87, 0.90); math.Abs(actual-1.4967202708492016) > 1e-9 {
+		t.Errorf("Expected scheduler ratio 1.4967, but got %v", actual)
+	}
+}
+
+func TestIntervalRatioMatchesScheduledIntervals(t *testing.T) {
+	config := DefaultSchedulerConfig()
+	config.MaximumInterval = math.MaxInt32
+	scheduler, _ := NewScheduler(config, testRand)
+	config.DesiredRetention = 0.8
+	lowRetention, _ := NewScheduler(config, testRand)
+
+	stability := 1000.0
+	actual := float64(lowRetention.CalculateNextReviewInterval(stability)) / float64(scheduler.CalculateNextReviewInterval(stability))
+	if math.Abs(actual-scheduler.IntervalRatio(0.8, 0.9)) > 1e-3 {
+		t.Errorf("Expected interval ratio %v, but got %v", scheduler.IntervalRatio(0.8, 0.9), actual)
+	}
+}
+
+func TestApproxInterval(t *testing.T) {
+	scheduler := createDefaultScheduler()
+	card := Card{CardID: 1, State: Review, Stability: 12.5, Difficulty: 5, Interval: 40 * dayDuration}
+
+	approx := card.ApproxInterval(scheduler)
+	if approx != scheduler.CalculateNextReviewInterval(card.Stability) {
+		t.Errorf("Expected %v, but got %v", scheduler.CalculateNextReviewInterval(card.Stability), approx)
+	}
+	if approx == card.Interval {
+		t.Errorf("Expected stability-implied interval to differ from the drifted stored interval")
+	}
+}
+
+func TestStabilityForInterval(t *testing.T) {
+	scheduler := createDefaultScheduler()
+
+	for _, days := range []int{1, 3, 10, 45, 200} {
+		interval := time.Duration(days) * dayDuration
+		stability := scheduler.StabilityForInterval(interval, 5)
+		if actual := scheduler.CalculateNextReviewInterval(stability); actual != interval {
+			t.Errorf("Expected %v for stability %v, but got %v", interval, stability, actual)
+		}
+	}
+
+	if stability := scheduler.StabilityForInterval(10*dayDuration, 5); math.Abs(stability-10) > 0.05 {
+		t.Errorf("Expected stability close to 10 days at 90%% retention, but got %v", stability)
+	}
+}
+
+func TestApproxIntervalUsesRetentionByDifficulty(t *testing.T) {
+	config := DefaultSchedulerConfig()
+	config.EnableFuzzing = false
+	config.RetentionByDifficulty = func(difficulty float64) float64 {
+		return 0.85 + (difficulty-1)/9*0.08
+	}
+	scheduler, _ := NewScheduler(config, testRand)
+
+	card := Card{CardID: 1, State: Review, Stability: 40, Difficulty: 9, Interval: 40 * dayDuration}
+	scheduled := scheduler.ReviewCard(card, Good, card.Interval)
+	if approx := scheduled.ApproxInterval(scheduler); approx != scheduled.Interval {
+		t.Errorf("Expected %v, but got %v", scheduled.Interval, approx)
+	}
+
+	stability := scheduler.StabilityForInterval(scheduled.Interval, scheduled.Difficulty)
+	if math.Abs(stability-scheduled.Stability)/scheduled.Stability > 0.02 {
+		t.Errorf("Expected stability close to %v, but got %v", scheduled.Stability, stability)
+	}
+}
+
+func TestPriorityScore(t *testing.T) {
+	scheduler := createDefaultScheduler()
+	overdueWeak := Card{CardID: 1, State: Review, Stability: 2, Difficulty: 7, Interval: 2 * dayDuration}
+	notDueStrong := Card{CardID: 2, State: Review, Stability: 60, Difficulty: 3, Interval: 60 * dayDuration}
+
+	weakScore := scheduler.PriorityScore(overdueWeak, 8*dayDuration)
+	strongScore := scheduler.PriorityScore(notDueStrong, 10*dayDuration)
+	if weakScore <= strongScore {
+		t.Errorf("Expected overdue weak card to outrank not-due strong card, but got %v vs %v", weakScore, strongScore)
+	}
+	if strongScore <= 0 {
+		t.Errorf("Expected positive score for a reviewed card, but got %v", strongScore)
+	}
+	if score := scheduler.PriorityScore(NewCard(3), 0); score != 0 {
+		t.Errorf("Expected score 0 for a new card, but got %v", score)
+	}
+}
+
+func TestUsingDefaultParameters(t *testing.T) {
+	if !createDefaultScheduler().UsingDefaultParameters() {
+		t.Errorf("Expected default scheduler to use default parameters")
+	}
+
+	config := DefaultSchedulerConfig()
+	config.Parameters[3] += 0.5
+	custom, _ := NewScheduler(config, testRand)
+	if custom.UsingDefaultParameters() {
+		t.Errorf("Expected custom scheduler not to use default parameters")
+	}
+
+	config = DefaultSchedulerConfig()
+	config.Parameters = config.Parameters[:19]
+	legacy, _ := NewScheduler(config, testRand)
+	if legacy.UsingDefaultParameters() {
+		t.Errorf("Expected truncated parameters not to match the defaults")
+	}
+}
+
+func TestIntervalAtAge(t *testing.T) {
+	scheduler := createDefaultScheduler()
+	card := Card{CardID: 1, State: Review, Stability: 17.3, Difficulty: 5, Interval: 17 * dayDuration}
+
+	for _, age := range []int{0, 30, 365} {
+		if interval := scheduler.IntervalAtAge(card, age); interval != scheduler.CalculateNextReviewInterval(card.Stability) {
+			t.Errorf("Expected %v at age %d, but got %v", scheduler.CalculateNextReviewInterval(card.Stability), age, interval)
+		}
+	}
+}
+
+func TestNormalizeCard(t *testing.T) {
+	scheduler := createDefaultScheduler()
+	lastReview := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	imported := Card{CardID: 1, State: Review, Stability: 10, Difficulty: 12, Interval: 90 * dayDuration, LastReview: lastReview}
+
+	normalized := scheduler.NormalizeCard(imported)
+	if normalized.Difficulty != maxDifficulty {
+		t.Errorf("Expected difficulty clamped to %v, but got %v", maxDifficulty, normalized.Difficulty)
+	}
+	if normalized.Interval != scheduler.CalculateNextReviewInterval(normalized.Stability) {
+		t.Errorf("Expected interval %v for stability %v, but got %v", scheduler.CalculateNextReviewInterval(normalized.Stability), normalized.Stability, normalized.Interval)
+	}
+	if normalized.Stability != imported.Stability {
+		t.Errorf("Expected stability to be preserved, but got %v", normalized.Stability)
+	}
+
+	keptDue := scheduler.NormalizeCardKeepDue(imported)
+	if !keptDue.Due().Equal(imported.Due()) {
+		t.Errorf("Expected due %v to be preserved, but got %v", imported.Due(), keptDue.Due())
+	}
+	if keptDue.Interval != scheduler.CalculateNextReviewInterval(keptDue.Stability) {
+		t.Errorf("Expected interval %v to match stability %v", keptDue.Interval, keptDue.Stability)
+	}
+
+	learning := Card{CardID: 2, State: Learning, Stability: 0, Difficulty: 0.5, Interval: 10 * time.Minute}
+	normalizedLearning := scheduler.NormalizeCard(learning)
+	if normalizedLearning.Interval != learning.Interval || normalizedLearning.Difficulty != minDifficulty || normalizedLearning.Stability != stabilityMin {
+		t.Errorf("Expected only memory state to be clamped for a learning card, but got %+v", normalizedLearning)
+	}
+}
+
+func TestScheduleDivergence(t *testing.T) {
+	a := createDefaultScheduler()
+	b := createDefaultScheduler()
+	stabilities := []float64{0.5, 1, 3, 10, 30, 100}
+
+	if divergence := ScheduleDivergence(a, b, stabilities); divergence != 0 {
+		t.Errorf("Expected zero divergence for identical schedulers, but got %v", divergence)
+	}
+
+	config := DefaultSchedulerConfig()
+	config.DesiredRetention = 0.8
+	lower, _ := NewScheduler(config, testRand)
+	if divergence := ScheduleDivergence(a, lower, stabilities); divergence <= 0 {
+		t.Errorf("Expected positive divergence for different retentions, but got %v", divergence)
+	}
+	if ScheduleDivergence(a, lower, stabilities) != ScheduleDivergence(lower, a, stabilities) {
+		t.Errorf("Expected divergence to be symmetric")
+	}
+}
+
+func TestMemoryStrength(t *testing.T) {
+	scheduler := createDefaultScheduler()
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	if strength := scheduler.MemoryStrength(NewCard(1), now); strength != 0 {
+		t.Errorf("Expected strength 0 for a new card, but got %v", strength)
+	}
+
+	mature := Card{CardID: 2, State: Review, Stability: 365, Difficulty: 3, Interval: 365 * dayDuration, LastReview: now}
+	if strength := scheduler.MemoryStrength(mature, now); strength < 0.99 {
+		t.Errorf("Expected strength near 1 for a fresh mature card, but got %v", strength)
+	}
+
+	weak := Card{CardID: 3, State: Review, Stability: 1, Difficulty: 8, Interval: dayDuration, LastReview: now.AddDate(0, 0, -20)}
+	if strength := scheduler.MemoryStrength(weak, now); strength > 0.05 {
+		t.Errorf("Expected low strength for an overdue weak card, but got %v", strength)
+	}
+}
+
+func TestTypicalGrowthFactor(t *testing.T) {
+	factor := createDefaultScheduler().TypicalGrowthFactor()
+	if factor < 1.5 || factor > 3.5 {
+		t.Errorf("Expected growth factor between 1.5 and 3.5, but got %v", factor)
+	}
+
+	config := DefaultSchedulerConfig()
+	config.DesiredRetention = 0.8
+	lower, _ := NewScheduler(config, testRand)
+	if lower.TypicalGrowthFactor() <= factor {
+		t.Errorf("Expected lower retention to grow intervals faster, but got %v vs %v", lower.TypicalGrowthFactor(), factor)
+	}
+}
+
+func TestSurvivesGap(t *testing.T) {
+	scheduler := createDefaultScheduler()
+	lastReview := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	now := lastReview.Add(3 * dayDuration)
+	trip := 14 * dayDuration
+
+	strong := Card{CardID: 1, State: Review, Stability: 200, Difficulty: 5, Interval: 180 * dayDuration}
+	if !scheduler.SurvivesGap(strong, lastReview, now, trip, 0.8) {
+		t.Errorf("Expected a high-stability card to survive a two-week gap")
+	}
+
+	weak := Card{CardID: 2, State: Review, Stability: 4, Difficulty: 5, Interval: 4 * dayDuration}
+	if scheduler.SurvivesGap(weak, lastReview, now, trip, 0.8) {
+		t.Errorf("Expected a low-stability card not to survive a two-week gap")
+	}
+
+	if scheduler.SurvivesGap(NewCard(3), lastReview, now, 0, 0.8) {
+		t.Errorf("Expected a new card not to survive any gap")
+	}
+}
+
+func TestOverrideDecay(t *testing.T) {
+	base := DefaultSchedulerConfig()
+	base.EnableFuzzing = false
+	base.DesiredRetention = 0.8
+	fitted, _ := NewScheduler(base, testRand)
+
+	pinned := 0.5
+	config := base
+	config.OverrideDecay = &pinned
+	scheduler, err := NewScheduler(config, testRand)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if decay, overridden := scheduler.Decay(); decay != 0.5 || !overridden {
+		t.Errorf("Expected overridden decay 0.5, but got %v (%v)", decay, overridden)
+	}
+	if decay, overridden := fitted.Decay(); decay != base.Parameters[20] || overridden {
+		t.Errorf("Expected fitted decay %v, but got %v (%v)", base.Parameters[20], decay, overridden)
+	}
+	if w := scheduler.EffectiveParameters(); w[20] != 0.5 || w[0] != base.Parameters[0] {
+		t.Errorf("Expected effective parameters with w[20]=0.5, but got %v", w)
+	}
+	if base.Parameters[20] == 0.5 {
+		t.Fatalf("Expected default decay to differ from the override")
+	}
+
+	if scheduler.CalculateNextReviewInterval(50) == fitted.CalculateNextReviewInterval(50) {
+		t.Errorf("Expected the decay override to change intervals")
+	}
+
+	// At the scheduled interval retrievability equals the desired retention,
+	// whatever the decay.
+	interval := scheduler.CalculateNextReviewInterval(50)
+	if r := scheduler.retrievability(50, interval); math.Abs(r-0.8) > 0.01 {
+		t.Errorf("Expected retrievability 0.8 at the scheduled interval, but got %v", r)
+	}
+	card := Card{CardID: 1, State: Review, Stability: 50, Difficulty: 5, Interval: interval}
+	expected := nextStability(scheduler.w, 5, 50, scheduler.retrievability(50, interval), Good)
+	if got := scheduler.ReviewCard(card, Good, interval).Stability; math.Abs(got-expected) > 1e-9 {
+		t.Errorf("Expected stability %v, but got %v", expected, got)
+	}
+
+	for _, invalid := range []float64{0.05, 0.9, math.NaN()} {
+		config.OverrideDecay = &invalid
+		if _, err := NewScheduler(config, testRand); err == nil {
+			t.Errorf("Expected an error for decay override %v", invalid)
+		}
+	}
+}
+
+func TestHalfLife(t *testing.T) {
+	scheduler := createDefaultScheduler()
+
+	halfLife := scheduler.HalfLife(10)
+	if r := scheduler.retrievability(10, halfLife); math.Abs(r-0.5) > 1e-4 {
+		t.Errorf("Expected retrievability 0.5 at the half-life, but got %v", r)
+	}
+	if halfLife <= 10*dayDuration {
+		t.Errorf("Expected the half-life to exceed the stability, but got %v", halfLife)
+	}
+
+	tiny := scheduler.HalfLife(0.0003)
+	if tiny <= 0 || tiny >= time.Hour || tiny%time.Minute != 0 {
+		t.Errorf("Expected a sub-hour half-life in whole minutes, but got %v", tiny)
+	}
+	if scheduler.HalfLife(0) != 0 {
+		t.Errorf("Expected zero half-life for zero stability")
+	}
+}
+
+func TestDecayWarnings(t *testing.T) {
+	scheduler := createDefaultScheduler()
+	lastReview := time.Date(2025, 2, 1, 8, 0, 0, 0, time.UTC)
+	card := Card{CardID: 1, State: Review, Stability: 15, Difficulty: 5, Interval: 15 * dayDuration}
+
+	thresholds := []float64{0.9, 0.8, 0.7}
+	warnings := scheduler.DecayWarnings(card, lastReview, thresholds)
+	if len(warnings) != 3 {
+		t.Fatalf("Expected 3 warnings, but got %v", warnings)
+	}
+	for i, warning := range warnings {
+		if i > 0 && !warning.After(warnings[i-1]) {
+			t.Errorf("Expected increasing times, but got %v", warnings)
+		}
+		if expected := scheduler.ThresholdDate(card, lastReview, thresholds[i]); !warning.Equal(expected) {
+			t.Errorf("Expected %v, but got %v", expected, warning)
+		}
+		if r := scheduler.retrievability(card.Stability, warning.Sub(lastReview)); math.Abs(r-thresholds[i]) > 1e-6 {
+			t.Errorf("Expected retrievability %v at the warning, but got %v", thresholds[i], r)
+		}
+	}
+
+	for _, invalid := range [][]float64{{0.9, 1.0}, {0.8, 0.9}, {0.5, 0}} {
+		if got := scheduler.DecayWarnings(card, lastReview, invalid); got != nil {
+			t.Errorf("Expected nil for thresholds %v, but got %v", invalid, got)
+		}
+	}
+}
+
+func TestRoundingError(t *testing.T) {
+	scheduler := createDefaultScheduler()
+	nonZero := false
+	for stability := 0.1; stability < 500; stability *= 1.07 {
+		roundingError := scheduler.RoundingError(stability)
+		if roundingError.Abs() > dayDuration/2 {
+			t.Errorf("Expected at most half a day for stability %v, but got %v", stability, roundingError)
+		}
+		if roundingError != 0 {
+			nonZero = true
+		}
+	}
+	if !nonZero {
+		t.Errorf("Expected some stabilities to round")
+	}
+	if got := scheduler.RoundingError(10); got.Abs() > time.Second {
+		t.Errorf("Expected no rounding when the interval equals the stability, but got %v", got)
+	}
+}
+
+func TestReviewRecommendation(t *testing.T) {
+	scheduler := createDefaultScheduler()
+	now := time.Date(2025, 6, 20, 18, 0, 0, 0, time.UTC)
+	card := Card{CardID: 1, State: Review, Stability: 10, Difficulty: 5, Interval: 10 * dayDuration}
+
+	cases := []struct {
+		lastReview time.Time
+		expected   string
+	}{
+		{now.AddDate(0, 0, -25), RecommendOverdue},
+		{now.AddDate(0, 0, -10).Add(-2 * time.Hour), RecommendDue},
+		{now.AddDate(0, 0, -3), RecommendNotDue},
+	}
+	for _, c := range cases {
+		if got := scheduler.ReviewRecommendation(card, c.lastReview, now); got != c.expected {
+			t.Errorf("Expected %q for a review at %v, but got %q", c.expected, c.lastReview, got)
+		}
+	}
+	if got := scheduler.ReviewRecommendation(NewCard(2), time.Time{}, now); got != RecommendDue {
+		t.Errorf("Expected a new card to be due today, but got %q", got)
+	}
+}
+
+// Expected values come from the Python port.
+func TestRetrievability(t *testing.T) {
+	scheduler := createDefaultScheduler()
+	cases := []struct {
+		card     Card
+		elapsed  time.Duration
+		expected float64
+	}{
+		{Card{State: Review, Stability: 1}, dayDuration, 0.9},
+		{Card{State: Review, Stability: 10}, 3 * dayDuration, 0.9610242690473834},
+		{Card{State: Review, Stability: 10}, 6 * time.Hour, 0.9962732946826292},
+		{Card{State: Review, Stability: 100}, 365 * dayDuration, 0.7908969875893878},
+		{Card{State: Learning, Stability: 0.5}, 10 * time.Minute, 0.9979167629385456},
+		{Card{State: Review, Stability: 10}, -time.Hour, 1},
+		{NewCard(1), dayDuration, 0},
+		{Card{State: Review}, dayDuration, 0},
+	}
+
+	for _, c := range cases {
+		if actual := scheduler.Retrievability(c.card, c.elapsed); math.Abs(actual-c.expected) > 1e-9 {
+			t.Errorf("Expected %v for stability %v after %v, but got %v", c.expected, c.card.Stability, c.elapsed, actual)
+		}
+	}
+}
+
+func TestGetRetrievability(t *testing.T) {
+	scheduler := createDefaultScheduler()
+	cases := []struct {
+		card     Card
+		elapsed  time.Duration
+		expected float64
+	}{
+		{Card{State: Review, Stability: 10}, 3 * dayDuration, 0.9610242690473834},
+		{Card{State: Review, Stability: 100}, 365 * dayDuration, 0.7908969875893878},
+		{NewCard(1), 30 * dayDuration, 1},
+		{Card{State: Learning}, dayDuration, 1},
+	}
+
+	for _, c := range cases {
+		actual := scheduler.GetRetrievability(c.card, c.elapsed)
+		if math.Abs(actual-c.expected) > 1e-9 {
+			t.Errorf("Expected %v for %v card with stability %v, but got %v", c.expected, c.card.State, c.card.Stability, actual)
+		}
+		if actual < 0 || actual > 1 {
+			t.Errorf("Expected a value in [0, 1], but got %v", actual)
+		}
+	}
+}
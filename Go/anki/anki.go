@@ -0,0 +1,42 @@
+package anki
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	fsrs "fsrs-go"
+)
+
+var ErrInvalidMemoryState = errors.New("invalid memory state")
+
+const day = 24 * time.Hour
+
+// FromAnkiMemoryState builds a Review card from the FSRS memory state Anki
+// stores per card. intervalDays is the card's ivl and dueOffset the number
+// of days from today until it is due, negative when overdue. The card's
+// LastReview is placed so that Due falls dueOffset days after the start of
+// today in today's location.
+func FromAnkiMemoryState(stability, difficulty float64, intervalDays int, dueOffset int, today time.Time) (fsrs.Card, error) {
+	if !(stability > 0) || math.IsInf(stability, 0) {
+		return fsrs.Card{}, fmt.Errorf("%w: stability %v must be positive", ErrInvalidMemoryState, stability)
+	}
+	if !(difficulty >= 1 && difficulty <= 10) {
+		return fsrs.Card{}, fmt.Errorf("%w: difficulty %v must be between 1 and 10", ErrInvalidMemoryState, difficulty)
+	}
+	if intervalDays < 1 {
+		return fsrs.Card{}, fmt.Errorf("%w: interval %d must be at least one day", ErrInvalidMemoryState, intervalDays)
+	}
+
+	year, month, date := today.Date()
+	due := time.Date(year, month, date+dueOffset, 0, 0, 0, 0, today.Location())
+	interval := time.Duration(intervalDays) * day
+	return fsrs.Card{
+		Interval:   interval,
+		Stability:  stability,
+		Difficulty: difficulty,
+		State:      fsrs.Review,
+		LastReview: due.Add(-interval),
+	}, nil
+}
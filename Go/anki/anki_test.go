@@ -0,0 +1,81 @@
+package anki
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	fsrs "fsrs-go"
+)
+
+func TestFromAnkiMemoryState(t *testing.T) {
+	today := time.Date(2025, 5, 20, 15, 30, 0, 0, time.UTC)
+	config := fsrs.DefaultSchedulerConfig()
+	config.EnableFuzzing = false
+	scheduler, _ := fsrs.NewDeterministicScheduler(config)
+
+	// There is no Anki collection to capture memory states from, so the
+	// stored fields come from replaying a history with the scheduler, as
+	// Anki does when it computes them under the same parameters.
+	source := fsrs.NewCard(1)
+	at := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+	for _, rating := range []fsrs.Rating{fsrs.Good, fsrs.Good, fsrs.Hard, fsrs.Good, fsrs.Good} {
+		result, _ := scheduler.ReviewAt(source, rating, at)
+		source = result.Card
+		at = source.Due()
+	}
+	if source.State != fsrs.Review {
+		t.Fatalf("Expected the history to end in Review, but got %v", source.State)
+	}
+	intervalDays := int(source.Interval / day)
+	year, month, date := source.Due().Date()
+	dueOffset := int(time.Date(year, month, date, 0, 0, 0, 0, time.UTC).Sub(time.Date(2025, 5, 20, 0, 0, 0, 0, time.UTC)) / day)
+
+	card, err := FromAnkiMemoryState(source.Stability, source.Difficulty, intervalDays, dueOffset, today)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := fsrs.Card{
+		Interval:   source.Interval,
+		Stability:  source.Stability,
+		Difficulty: source.Difficulty,
+		State:      fsrs.Review,
+		LastReview: time.Date(year, month, date, 0, 0, 0, 0, time.UTC).Add(-source.Interval),
+	}
+	if card != expected {
+		t.Errorf("Expected %+v, but got %+v", expected, card)
+	}
+	if y, m, d := card.Due().Date(); y != year || m != month || d != date {
+		t.Errorf("Expected due on %v, but got %v", source.Due(), card.Due())
+	}
+
+	overdue, _ := FromAnkiMemoryState(source.Stability, source.Difficulty, intervalDays, -2, today)
+	if due := time.Date(2025, 5, 18, 0, 0, 0, 0, time.UTC); !overdue.Due().Equal(due) {
+		t.Errorf("Expected due %v, but got %v", due, overdue.Due())
+	}
+
+	imported := scheduler.ReviewCard(card, fsrs.Good, card.Interval)
+	replayed := scheduler.ReviewCard(source, fsrs.Good, source.Interval)
+	if imported.Stability != replayed.Stability || imported.Difficulty != replayed.Difficulty || imported.Interval != replayed.Interval {
+		t.Errorf("Expected an imported card to schedule like its source %+v, but got %+v", replayed, imported)
+	}
+}
+
+func TestFromAnkiMemoryStateValidation(t *testing.T) {
+	today := time.Date(2025, 5, 20, 0, 0, 0, 0, time.UTC)
+	cases := []struct {
+		stability, difficulty float64
+		interval              int
+	}{
+		{0, 5, 10},
+		{-1, 5, 10},
+		{10, 0.5, 10},
+		{10, 10.5, 10},
+		{10, 5, 0},
+	}
+	for _, c := range cases {
+		if _, err := FromAnkiMemoryState(c.stability, c.difficulty, c.interval, 0, today); !errors.Is(err, ErrInvalidMemoryState) {
+			t.Errorf("Expected ErrInvalidMemoryState for %+v, but got %v", c, err)
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package fsrs
+
+import (
+	"fmt"
+	"math"
+)
+
+const anomalyStabilityTolerance = 0.1
+
+// DetectAnomalies replays every card's logs without fuzzing and describes
+// each inconsistency it finds: a log recorded in a state the replay was
+// not in, a stored state or stability that differs from the replay by
+// more than 10%, a Review interval outside the fuzz range of its
+// stability, and logs of unknown cards.
+func DetectAnomalies(s *Scheduler, cards []Card, logs []ReviewLog) []string {
+	cardIDs, grouped := logsByCard(logs)
+	known := make(map[int64]bool, len(cards))
+	var anomalies []string
+	for _, card := range cards {
+		known[card.CardID] = true
+		replayed := NewCard(card.CardID)
+		for _, log := range grouped[card.CardID] {
+			if log.State != replayed.State {
+				anomalies = append(anomalies, fmt.Sprintf("card %d: review at %v was logged in state %v, but replay was in %v",
+					card.CardID, log.ReviewTime, log.State, replayed.State))
+			}
+			replayed = s.previewCard(replayed, log.Rating, log.Elapsed)
+		}
+
+		if card.State != replayed.State {
+			anomalies = append(anomalies, fmt.Sprintf("card %d: state is %v, but replay ends in %v", card.CardID, card.State, replayed.State))
+		}
+		if replayed.State != New && math.Abs(card.Stability-replayed.Stability) > anomalyStabilityTolerance*replayed.Stability {
+			anomalies = append(anomalies, fmt.Sprintf("card %d: stability is %.4g, but replay gives %.4g", card.CardID, card.Stability, replayed.Stability))
+		}
+		if card.State == Review && card.Stability > 0 {
+			expected := s.intervalForRetention(card.Stability, s.desiredRetention(card.Difficulty))
+			minInterval, maxInterval := s.FuzzBounds(expected)
+			if card.Interval < minInterval || card.Interval > maxInterval {
+				anomalies = append(anomalies, fmt.Sprintf("card %d: interval is %v, but stability %.4g gives %v to %v",
+					card.CardID, card.Interval, card.Stability, minInterval, maxInterval))
+			}
+		}
+	}
+
+	for _, cardID := range cardIDs {
+		if !known[cardID] {
+			anomalies = append(anomalies, fmt.Sprintf("card %d: has %d logs but is not in the deck", cardID, len(grouped[cardID])))
+		}
+	}
+	return anomalies
+}
@@ -0,0 +1,54 @@
+package fsrs
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDetectAnomalies(t *testing.T) {
+	config := DefaultSchedulerConfig()
+	config.EnableFuzzing = false
+	scheduler, _ := NewScheduler(config, testRand)
+	start := time.Date(2025, 4, 1, 9, 0, 0, 0, time.UTC)
+
+	var logs []ReviewLog
+	history := func(cardID int64) Card {
+		card := NewCard(cardID)
+		now := start
+		for _, rating := range []Rating{Good, Good, Good, Good} {
+			result, _ := scheduler.ReviewAt(card, rating, now)
+			logs = append(logs, result.Log)
+			card = result.Card
+			now = now.Add(card.Interval)
+		}
+		return card
+	}
+	clean := history(1)
+	corrupted := history(2)
+	corrupted.Stability *= 3
+
+	anomalies := DetectAnomalies(scheduler, []Card{clean, corrupted}, logs)
+	if len(anomalies) == 0 {
+		t.Fatalf("Expected the corrupted card to be reported")
+	}
+	for _, anomaly := range anomalies {
+		if !strings.HasPrefix(anomaly, "card 2:") {
+			t.Errorf("Expected only card 2 to be reported, but got %q", anomaly)
+		}
+	}
+	if !strings.Contains(strings.Join(anomalies, "\n"), "stability") {
+		t.Errorf("Expected a stability anomaly, but got %v", anomalies)
+	}
+
+	if anomalies := DetectAnomalies(scheduler, []Card{clean}, logs[:4]); len(anomalies) != 0 {
+		t.Errorf("Expected no anomalies for a clean card, but got %v", anomalies)
+	}
+
+	skipped := clean
+	skipped.State = Relearning
+	anomalies = DetectAnomalies(scheduler, []Card{skipped}, logs[:4])
+	if len(anomalies) != 1 || !strings.Contains(anomalies[0], "state is Relearning, but replay ends in Review") {
+		t.Errorf("Expected a state anomaly, but got %v", anomalies)
+	}
+}
@@ -0,0 +1,88 @@
+package fsrs
+
+import (
+	"fmt"
+	"time"
+)
+
+type ScheduledCard struct {
+	Card Card
+	Tags []string
+}
+
+type ScheduleConstraints struct {
+	Deadline            time.Time
+	MaxIntervalByTag    map[string]time.Duration
+	RetrievabilityFloor float64
+	Checkpoint          time.Time
+}
+
+type ConstraintKind int
+
+const (
+	DeadlineConstraint       ConstraintKind = 0
+	TagIntervalConstraint    ConstraintKind = 1
+	RetrievabilityConstraint ConstraintKind = 2
+)
+
+// ScheduleViolation describes a card breaking one constraint. Excess is
+// measured in days for deadline and interval constraints and as the
+// retrievability shortfall below the floor for retrievability constraints.
+type ScheduleViolation struct {
+	CardID     int64
+	Constraint ConstraintKind
+	Tag        string
+	Excess     float64
+	Message    string
+}
+
+// AuditSchedule reports violations in input card order, then in
+// ConstraintKind order. Zero-valued constraints are not checked.
+func (s *Scheduler) AuditSchedule(cards []ScheduledCard, constraints ScheduleConstraints) []ScheduleViolation {
+	var violations []ScheduleViolation
+	for _, scheduled := range cards {
+		card := scheduled.Card
+		if card.State == New {
+			continue
+		}
+		due := card.Due()
+
+		if !constraints.Deadline.IsZero() && due.After(constraints.Deadline) {
+			excess := due.Sub(constraints.Deadline).Hours() / dayDuration.Hours()
+			violations = append(violations, ScheduleViolation{
+				CardID:     card.CardID,
+				Constraint: DeadlineConstraint,
+				Excess:     excess,
+				Message:    fmt.Sprintf("card %d is due %.1f days after the deadline", card.CardID, excess),
+			})
+		}
+
+		for _, tag := range scheduled.Tags {
+			limit, ok := constraints.MaxIntervalByTag[tag]
+			if !ok || card.Interval <= limit {
+				continue
+			}
+			excess := (card.Interval - limit).Hours() / dayDuration.Hours()
+			violations = append(violations, ScheduleViolation{
+				CardID:     card.CardID,
+				Constraint: TagIntervalConstraint,
+				Tag:        tag,
+				Excess:     excess,
+				Message:    fmt.Sprintf("card %d interval exceeds the %q cap by %.1f days", card.CardID, tag, excess),
+			})
+		}
+
+		if constraints.RetrievabilityFloor > 0 && !constraints.Checkpoint.IsZero() && due.After(constraints.Checkpoint) {
+			r := s.retrievability(card.Stability, constraints.Checkpoint.Sub(card.LastReview))
+			if r < constraints.RetrievabilityFloor {
+				violations = append(violations, ScheduleViolation{
+					CardID:     card.CardID,
+					Constraint: RetrievabilityConstraint,
+					Excess:     constraints.RetrievabilityFloor - r,
+					Message:    fmt.Sprintf("card %d retrievability at the checkpoint is %.3f, below the floor %.3f", card.CardID, r, constraints.RetrievabilityFloor),
+				})
+			}
+		}
+	}
+	return violations
+}
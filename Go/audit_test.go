@@ -0,0 +1,63 @@
+package fsrs
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestAuditSchedule(t *testing.T) {
+	scheduler := createDefaultScheduler()
+	now := time.Date(2025, 5, 1, 12, 0, 0, 0, time.UTC)
+	exam := now.AddDate(0, 0, 14)
+
+	cards := []ScheduledCard{
+		{Card: Card{CardID: 1, State: Review, Stability: 5, Difficulty: 5, Interval: 5 * dayDuration, LastReview: now}},
+		{Card: Card{CardID: 2, State: Review, Stability: 30, Difficulty: 5, Interval: 30 * dayDuration, LastReview: now}},
+		{Card: Card{CardID: 3, State: Review, Stability: 3, Difficulty: 5, Interval: 12 * dayDuration, LastReview: now}, Tags: []string{"verbs"}},
+		{Card: Card{CardID: 4, State: Review, Stability: 2, Difficulty: 8, Interval: 20 * dayDuration, LastReview: now}},
+		{Card: NewCard(5), Tags: []string{"verbs"}},
+	}
+	constraints := ScheduleConstraints{
+		Deadline:            exam,
+		MaxIntervalByTag:    map[string]time.Duration{"verbs": 7 * dayDuration},
+		RetrievabilityFloor: 0.8,
+		Checkpoint:          exam,
+	}
+
+	violations := scheduler.AuditSchedule(cards, constraints)
+
+	expected := []struct {
+		cardID     int64
+		constraint ConstraintKind
+		excess     float64
+	}{
+		{2, DeadlineConstraint, 16},
+		{3, TagIntervalConstraint, 5},
+		{4, DeadlineConstraint, 6},
+		{4, RetrievabilityConstraint, 0.8 - scheduler.retrievability(2, 14*dayDuration)},
+	}
+
+	if len(violations) != len(expected) {
+		t.Fatalf("Expected %d violations, but got %d: %+v", len(expected), len(violations), violations)
+	}
+	for i, e := range expected {
+		v := violations[i]
+		if v.CardID != e.cardID || v.Constraint != e.constraint {
+			t.Errorf("Expected violation %d for card %d kind %v, but got card %d kind %v", i, e.cardID, e.constraint, v.CardID, v.Constraint)
+		}
+		if math.Abs(v.Excess-e.excess) > 1e-9 {
+			t.Errorf("Expected excess %v for violation %d, but got %v", e.excess, i, v.Excess)
+		}
+		if v.Message == "" {
+			t.Errorf("Expected a message for violation %d", i)
+		}
+	}
+	if violations[1].Tag != "verbs" {
+		t.Errorf("Expected tag verbs, but got %q", violations[1].Tag)
+	}
+
+	if violations := scheduler.AuditSchedule(cards, ScheduleConstraints{}); len(violations) != 0 {
+		t.Errorf("Expected no violations without constraints, but got %+v", violations)
+	}
+}
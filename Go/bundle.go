@@ -0,0 +1,36 @@
+package fsrs
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+const bundleVersion = 1
+
+var ErrBundleVersion = errors.New("unsupported bundle version")
+
+type bundle struct {
+	Version int         `json:"version"`
+	Cards   []Card      `json:"cards"`
+	Logs    []ReviewLog `json:"logs"`
+}
+
+func MarshalBundle(cards []Card, logs []ReviewLog) ([]byte, error) {
+	return json.Marshal(bundle{
+		Version: bundleVersion,
+		Cards:   cards,
+		Logs:    logs,
+	})
+}
+
+func UnmarshalBundle(data []byte) ([]Card, []ReviewLog, error) {
+	var b bundle
+	if err := json.Unmarshal(data, &b); err != nil {
+		return nil, nil, err
+	}
+	if b.Version != bundleVersion {
+		return nil, nil, fmt.Errorf("%w: got %d, supported %d", ErrBundleVersion, b.Version, bundleVersion)
+	}
+	return b.Cards, b.Logs, nil
+}
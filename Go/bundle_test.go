@@ -0,0 +1,56 @@
+package fsrs
+
+import (
+	"errors"
+	"math/rand"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestBundleRoundTrip(t *testing.T) {
+	scheduler, _ := NewScheduler(DefaultSchedulerConfig(), rand.New(rand.NewSource(7)))
+	now := time.Date(2025, 2, 1, 8, 30, 0, 0, time.UTC)
+
+	var cards []Card
+	var logs []ReviewLog
+	for id := int64(1); id <= 5; id++ {
+		card := NewCard(id)
+		reviewTime := now
+		for _, rating := range []Rating{Again, Good, Good, Hard, Easy} {
+			reviewTime = reviewTime.Add(card.Interval)
+			card = scheduler.ReviewCard(card, rating, card.Interval)
+			card.LastReview = reviewTime
+			logs = append(logs, ReviewLog{CardID: id, Rating: rating, ReviewTime: reviewTime})
+		}
+		cards = append(cards, card)
+	}
+	cards = append(cards, NewCard(6), Card{CardID: 7, State: Learning, Stability: 1, Difficulty: 5, SameDayUpdates: 3, ParamsID: "shared"})
+
+	data, err := MarshalBundle(cards, logs)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	restoredCards, restoredLogs, err := UnmarshalBundle(data)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(cards, restoredCards) {
+		t.Errorf("Expected cards %+v, but got %+v", cards, restoredCards)
+	}
+	if !reflect.DeepEqual(logs, restoredLogs) {
+		t.Errorf("Expected logs %+v, but got %+v", logs, restoredLogs)
+	}
+}
+
+func TestBundleRejectsVersionMismatch(t *testing.T) {
+	data := []byte(`{"version": 2, "cards": [], "logs": []}`)
+	if _, _, err := UnmarshalBundle(data); !errors.Is(err, ErrBundleVersion) {
+		t.Errorf("Expected ErrBundleVersion, but got %v", err)
+	}
+
+	if _, _, err := UnmarshalBundle([]byte(`{"cards": []}`)); !errors.Is(err, ErrBundleVersion) {
+		t.Errorf("Expected ErrBundleVersion for a missing version, but got %v", err)
+	}
+}
@@ -0,0 +1,67 @@
+package fsrs
+
+import "time"
+
+const (
+	clampWarnFraction = 0.05
+	clampBadFraction  = 0.20
+)
+
+// ClampStats describes the Review cards whose stability implies an interval
+// beyond MaximumInterval. AverageExcess is the mean amount cut off and
+// EffectiveRetention the mean retrievability those cards reach at the
+// clamped interval, which is above the target. Severity warns when more
+// than 5% of Review cards are clamped and is bad above 20%.
+type ClampStats struct {
+	ClampedFraction    float64
+	AverageExcess      time.Duration
+	EffectiveRetention float64
+	Severity           Severity
+}
+
+func ClampReport(cards []Card, scheduler *Scheduler) ClampStats {
+	maxInterval := time.Duration(scheduler.config.MaximumInterval) * dayDuration
+	var stats ClampStats
+	reviews, clamped := 0, 0
+	var excess time.Duration
+	retention := 0.0
+	for _, card := range cards {
+		if card.State != Review || card.Stability <= 0 {
+			continue
+		}
+		reviews++
+		days := impliedIntervalDays(scheduler.factor, scheduler.desiredRetention(card.Difficulty), scheduler.decay, scheduler.intervalScale(), card.Stability)
+		implied := time.Duration(days * float64(dayDuration))
+		if implied <= maxInterval {
+			continue
+		}
+		clamped++
+		excess += implied - maxInterval
+		retention += scheduler.retrievability(card.Stability, maxInterval)
+	}
+
+	stats.ClampedFraction = fraction(clamped, reviews)
+	if clamped > 0 {
+		stats.AverageExcess = excess / time.Duration(clamped)
+		stats.EffectiveRetention = retention / float64(clamped)
+	}
+	stats.Severity = severity(stats.ClampedFraction, clampWarnFraction, clampBadFraction)
+	return stats
+}
+
+// MaxIntervalImpact counts the Review cards whose current interval is
+// longer than newMax days and the average number of days they would lose.
+func (s *Scheduler) MaxIntervalImpact(cards []Card, newMax int) (affected int, avgReductionDays float64) {
+	limit := time.Duration(newMax) * dayDuration
+	var reduction time.Duration
+	for _, card := range cards {
+		if card.State == Review && card.Interval > limit {
+			affected++
+			reduction += card.Interval - limit
+		}
+	}
+	if affected == 0 {
+		return 0, 0
+	}
+	return affected, reduction.Hours() / dayDuration.Hours() / float64(affected)
+}
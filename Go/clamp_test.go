@@ -0,0 +1,60 @@
+package fsrs
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestClampReport(t *testing.T) {
+	config := DefaultSchedulerConfig()
+	config.MaximumInterval = 100
+	scheduler, _ := NewScheduler(config, testRand)
+
+	var cards []Card
+	for i, stability := range []float64{5, 20, 60, 90, 150, 300} {
+		cards = append(cards, Card{CardID: int64(i + 1), State: Review, Stability: stability, Difficulty: 5, Interval: 100 * dayDuration})
+	}
+	cards = append(cards, NewCard(7), Card{CardID: 8, State: Learning, Stability: 500, Difficulty: 5, Interval: time.Minute})
+
+	stats := ClampReport(cards, scheduler)
+	if math.Abs(stats.ClampedFraction-2.0/6.0) > 1e-9 {
+		t.Errorf("Expected 2 of 6 review cards clamped, but got %v", stats.ClampedFraction)
+	}
+	expectedExcess := time.Duration((50 + 200) / 2 * float64(dayDuration))
+	if diff := (stats.AverageExcess - expectedExcess).Abs(); diff > time.Hour {
+		t.Errorf("Expected an average excess near %v, but got %v", expectedExcess, stats.AverageExcess)
+	}
+	expectedRetention := (scheduler.retrievability(150, 100*dayDuration) + scheduler.retrievability(300, 100*dayDuration)) / 2
+	if math.Abs(stats.EffectiveRetention-expectedRetention) > 1e-12 || stats.EffectiveRetention <= 0.9 {
+		t.Errorf("Expected effective retention %v above the target, but got %v", expectedRetention, stats.EffectiveRetention)
+	}
+	if stats.Severity != SeverityBad {
+		t.Errorf("Expected a bad severity, but got %v", stats.Severity)
+	}
+
+	if stats := ClampReport(cards[:3], scheduler); stats != (ClampStats{}) {
+		t.Errorf("Expected nothing clamped, but got %+v", stats)
+	}
+}
+
+func TestMaxIntervalImpact(t *testing.T) {
+	scheduler := createDefaultScheduler()
+	var cards []Card
+	for i, stability := range []float64{30, 200, 400, 900, 1500} {
+		cards = append(cards, Card{CardID: int64(i + 1), State: Review, Stability: stability, Difficulty: 5, Interval: scheduler.CalculateNextReviewInterval(stability)})
+	}
+	cards = append(cards, Card{CardID: 6, State: Learning, Stability: 900, Difficulty: 5, Interval: 10 * time.Minute})
+
+	affected, reduction := scheduler.MaxIntervalImpact(cards, 365)
+	if affected != 3 {
+		t.Errorf("Expected the 3 long-interval cards to be affected, but got %d", affected)
+	}
+	if expected := (400.0 + 900 + 1500 - 3*365) / 3; math.Abs(reduction-expected) > 1e-9 {
+		t.Errorf("Expected an average reduction of %v days, but got %v", expected, reduction)
+	}
+
+	if affected, reduction := scheduler.MaxIntervalImpact(cards, 36500); affected != 0 || reduction != 0 {
+		t.Errorf("Expected a higher maximum to affect nothing, but got %d and %v", affected, reduction)
+	}
+}
@@ -0,0 +1,37 @@
+package fsrs
+
+import (
+	"math"
+	"time"
+)
+
+// IntervalFromDays converts a whole-day interval, as stored by day-based
+// schedulers, into a Card Interval. Review cards use days directly. Learning
+// and Relearning cards take their interval from steps[step], because a day
+// count cannot express a sub-day step; when step is outside steps they
+// fall back to days, or the last step when days is zero. New cards have no
+// interval.
+func IntervalFromDays(days uint32, state State, step int, steps []time.Duration) time.Duration {
+	switch state {
+	case New:
+		return 0
+	case Learning, Relearning:
+		if step >= 0 && step < len(steps) {
+			return steps[step]
+		}
+		if days == 0 && len(steps) > 0 {
+			return steps[len(steps)-1]
+		}
+	}
+	return time.Duration(days) * dayDuration
+}
+
+// IntervalToDays is the inverse of IntervalFromDays. It rounds to the
+// nearest day, so sub-day learning steps become 0 and are only recovered
+// from the step configuration.
+func IntervalToDays(interval time.Duration) uint32 {
+	if interval <= 0 {
+		return 0
+	}
+	return uint32(math.Round(interval.Hours() / dayDuration.Hours()))
+}
@@ -0,0 +1,56 @@
+package fsrs
+
+import (
+	"testing"
+	"time"
+)
+
+func TestIntervalFromDays(t *testing.T) {
+	steps := []time.Duration{time.Minute, 10 * time.Minute, 2 * dayDuration}
+	cases := []struct {
+		days     uint32
+		state    State
+		step     int
+		expected time.Duration
+	}{
+		{0, New, 0, 0},
+		{12, Review, 0, 12 * dayDuration},
+		{0, Learning, 1, 10 * time.Minute},
+		{0, Relearning, 0, time.Minute},
+		{2, Learning, 2, 2 * dayDuration},
+		{3, Learning, 7, 3 * dayDuration},
+		{0, Learning, 7, 2 * dayDuration},
+	}
+	for _, c := range cases {
+		if got := IntervalFromDays(c.days, c.state, c.step, steps); got != c.expected {
+			t.Errorf("Expected %v for %d days in state %v step %d, but got %v", c.expected, c.days, c.state, c.step, got)
+		}
+	}
+	if got := IntervalFromDays(0, Learning, 0, nil); got != 0 {
+		t.Errorf("Expected 0 without steps, but got %v", got)
+	}
+}
+
+func TestIntervalToDaysRoundTrip(t *testing.T) {
+	steps := []time.Duration{time.Minute, 10 * time.Minute}
+
+	if days := IntervalToDays(15 * dayDuration); days != 15 || IntervalFromDays(days, Review, 0, steps) != 15*dayDuration {
+		t.Errorf("Expected Review intervals to round-trip, but got %d days", days)
+	}
+
+	// Sub-day steps are lossy as days but recovered from the step.
+	days := IntervalToDays(10 * time.Minute)
+	if days != 0 {
+		t.Errorf("Expected a 10 minute step to store as 0 days, but got %d", days)
+	}
+	if got := IntervalFromDays(days, Learning, 1, steps); got != 10*time.Minute {
+		t.Errorf("Expected the step to restore 10m, but got %v", got)
+	}
+
+	if days := IntervalToDays(36 * time.Hour); days != 2 {
+		t.Errorf("Expected 36h to round to 2 days, but got %d", days)
+	}
+	if days := IntervalToDays(-time.Hour); days != 0 {
+		t.Errorf("Expected 0 for a negative interval, but got %d", days)
+	}
+}
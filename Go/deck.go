@@ -0,0 +1,282 @@
+package fsrs
+
+import (
+	"cmp"
+	"math"
+	"slices"
+	"time"
+)
+
+func (s *Scheduler) ReviewBudgetForDeck(cards []Card, targetRetention float64, byDay int) int {
+	if len(cards) == 0 || targetRetention <= 0 {
+		return 0
+	}
+	targetRetention = min(targetRetention, 1.0)
+	horizon := time.Duration(max(byDay, 0)) * dayDuration
+
+	type simulatedCard struct {
+		card       Card
+		lastReview time.Duration
+	}
+
+	simulated := make([]simulatedCard, len(cards))
+	for i, card := range cards {
+		simulated[i] = simulatedCard{card: card}
+	}
+
+	reviews := 0
+	for {
+		total := 0.0
+		weakest := -1
+		weakestRetrievability := 0.0
+		for i, sim := range simulated {
+			r := 0.0
+			if sim.card.State != New {
+				r = s.retrievability(sim.card.Stability, horizon-sim.lastReview)
+			}
+			total += r
+			if weakest < 0 || r < weakestRetrievability {
+				weakest = i
+				weakestRetrievability = r
+			}
+		}
+
+		if total/float64(len(simulated)) >= targetRetention || weakestRetrievability >= 1.0 {
+			return reviews
+		}
+
+		sim := &simulated[weakest]
+		reviewAt := horizon
+		if sim.card.State != New {
+			if due := sim.lastReview + sim.card.Interval; due < horizon {
+				reviewAt = due
+			}
+		} else if horizon > 0 {
+			reviewAt = 0
+		}
+
+		sim.card = s.previewCard(sim.card, Good, reviewAt-sim.lastReview)
+		sim.lastReview = reviewAt
+		reviews++
+	}
+}
+
+func (s *Scheduler) SimulateSkip(cards []Card, skipDay, nextDay time.Time) (extraReviews int) {
+	skipEnd := startOfDay(skipDay).AddDate(0, 0, 1)
+	if nextDay.Before(skipEnd) {
+		return 0
+	}
+	for _, card := range cards {
+		if card.State != New && card.Due().Before(skipEnd) {
+			extraReviews++
+		}
+	}
+	return extraReviews
+}
+
+func startOfDay(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, t.Location())
+}
+
+// DeckRetentionScore returns the mean current retrievability of the
+// non-New cards, or 0 when there are none.
+func (s *Scheduler) DeckRetentionScore(cards []Card, now time.Time) float64 {
+	total := 0.0
+	count := 0
+	for _, card := range cards {
+		if card.State == New {
+			continue
+		}
+		total += s.cardRetrievability(card, now)
+		count++
+	}
+	if count == 0 {
+		return 0
+	}
+	return total / float64(count)
+}
+
+// ForgettingIndex returns 1 - DeckRetentionScore, or 0 when no card has
+// been reviewed.
+func (s *Scheduler) ForgettingIndex(cards []Card, now time.Time) float64 {
+	for _, card := range cards {
+		if card.State != New {
+			return 1.0 - s.DeckRetentionScore(cards, now)
+		}
+	}
+	return 0
+}
+
+func (s *Scheduler) cardRetrievability(card Card, now time.Time) float64 {
+	return s.retrievability(card.Stability, now.Sub(card.LastReview))
+}
+
+func (s *Scheduler) CardsToReset(cards []Card, now time.Time, forgottenThreshold float64) []Card {
+	var forgotten []Card
+	for _, card := range cards {
+		if card.State != New && s.cardRetrievability(card, now) < forgottenThreshold {
+			forgotten = append(forgotten, card)
+		}
+	}
+	return forgotten
+}
+
+// PreTripCramList returns the reviewed cards whose retrievability would fall
+// below minRetention before tripEnd, so they should be studied before
+// tripStart.
+func (s *Scheduler) PreTripCramList(cards []Card, tripStart, tripEnd time.Time, minRetention float64) []Card {
+	var atRisk []Card
+	for _, card := range cards {
+		if card.State == New {
+			continue
+		}
+		if !s.SurvivesGap(card, card.LastReview, tripStart, tripEnd.Sub(tripStart), minRetention) {
+			atRisk = append(atRisk, card)
+		}
+	}
+	return atRisk
+}
+
+// NextAvailableDue moves the card's due date onto a weekday marked in
+// availableWeekdays (indexed by time.Weekday) in loc. It picks the nearest
+// such day within the fuzz band around the due date, preferring the
+// earlier one on ties, and otherwise the first available day after it.
+// Overdue cards are treated as due at now.
+func (s *Scheduler) NextAvailableDue(card Card, now time.Time, availableWeekdays [7]bool, loc *time.Location) time.Time {
+	due := card.Due().In(loc)
+	if due.Before(now) {
+		return nextAvailableDay(now.In(loc), availableWeekdays)
+	}
+
+	minInterval, maxInterval := s.FuzzBounds(card.Interval)
+	earliest := startOfDay(card.LastReview.Add(minInterval).In(loc))
+	latest := card.LastReview.Add(maxInterval).In(loc)
+	if earliest.Before(startOfDay(now.In(loc))) {
+		earliest = startOfDay(now.In(loc))
+	}
+	for d := 0; ; d++ {
+		before, after := due.AddDate(0, 0, -d), due.AddDate(0, 0, d)
+		beforeInBand, afterInBand := !before.Before(earliest), !after.After(latest)
+		if !beforeInBand && !afterInBand {
+			break
+		}
+		if beforeInBand && availableWeekdays[before.Weekday()] {
+			return before
+		}
+		if afterInBand && availableWeekdays[after.Weekday()] {
+			return after
+		}
+	}
+	return nextAvailableDay(due, availableWeekdays)
+}
+
+func nextAvailableDay(t time.Time, availableWeekdays [7]bool) time.Time {
+	for d := range 7 {
+		if day := t.AddDate(0, 0, d); availableWeekdays[day.Weekday()] {
+			return day
+		}
+	}
+	return t
+}
+
+// SelectForMinForgetting picks up to maxCards reviewed cards whose review
+// at now lowers ForgettingIndex the most. A review restores retrievability
+// to 1, so each card's gain is its current forgetting probability and the
+// greedy choice is the cards with the lowest retrievability, ties broken
+// by CardID.
+func (s *Scheduler) SelectForMinForgetting(cards []Card, now time.Time, maxCards int) []Card {
+	type candidate struct {
+		card           Card
+		retrievability float64
+	}
+	var candidates []candidate
+	for _, card := range cards {
+		if card.State != New {
+			candidates = append(candidates, candidate{card, s.cardRetrievability(card, now)})
+		}
+	}
+	slices.SortFunc(candidates, func(a, b candidate) int {
+		return cmp.Or(cmp.Compare(a.retrievability, b.retrievability), cmp.Compare(a.card.CardID, b.card.CardID))
+	})
+
+	selected := make([]Card, 0, min(max(maxCards, 0), len(candidates)))
+	for _, c := range candidates[:cap(selected)] {
+		selected = append(selected, c.card)
+	}
+	return selected
+}
+
+// ForecastHeatmap counts reviewed cards by due date on a weeks×7 grid in
+// now's location, one row per week starting on Sunday and columns indexed
+// by time.Weekday. The first row is the week containing now; overdue cards
+// count on today.
+func (s *Scheduler) ForecastHeatmap(cards []Card, now time.Time, weeks int) [][7]int {
+	if weeks <= 0 {
+		return nil
+	}
+	grid := make([][7]int, weeks)
+	today := startOfDay(now)
+	weekStart := today.AddDate(0, 0, -int(today.Weekday()))
+	for _, card := range cards {
+		if card.State == New {
+			continue
+		}
+		due := startOfDay(card.Due().In(now.Location()))
+		if due.Before(today) {
+			due = today
+		}
+		days := int(math.Round(due.Sub(weekStart).Hours() / 24))
+		if week := days / 7; week < weeks {
+			grid[week][due.Weekday()]++
+		}
+	}
+	return grid
+}
+
+// RecommendedBatchSize returns how many of the cards due at now fit in a
+// session of targetSessionMinutes at perCardSeconds each. Take them in
+// urgency order, as SelectForMinForgetting returns them.
+func (s *Scheduler) RecommendedBatchSize(cards []Card, now time.Time, targetSessionMinutes, perCardSeconds float64) int {
+	if targetSessionMinutes <= 0 || perCardSeconds <= 0 {
+		return 0
+	}
+	due := 0
+	for _, card := range cards {
+		if card.State != New && !card.Due().After(now) {
+			due++
+		}
+	}
+	return min(due, int(targetSessionMinutes*60/perCardSeconds))
+}
+
+// RecoveryPlan spreads the cards due at or before now over the coming days,
+// perDay at a time, for a staged return after a break. The least
+// retrievable cards come first, ties broken by CardID. It maps each day
+// offset from now, starting at 0, to the IDs of the cards for that day,
+// and returns nil when perDay is not positive.
+func (s *Scheduler) RecoveryPlan(cards []Card, now time.Time, perDay int) map[int][]int64 {
+	if perDay <= 0 {
+		return nil
+	}
+
+	type overdueCard struct {
+		id             int64
+		retrievability float64
+	}
+	var overdue []overdueCard
+	for _, card := range cards {
+		if card.State != New && !card.Due().After(now) {
+			overdue = append(overdue, overdueCard{card.CardID, s.cardRetrievability(card, now)})
+		}
+	}
+	slices.SortFunc(overdue, func(a, b overdueCard) int {
+		return cmp.Or(cmp.Compare(a.retrievability, b.retrievability), cmp.Compare(a.id, b.id))
+	})
+
+	plan := make(map[int][]int64)
+	for i, card := range overdue {
+		plan[i/perDay] = append(plan[i/perDay], card.id)
+	}
+	return plan
+}
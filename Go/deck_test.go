@@ -0,0 +1,307 @@
+package fsrs
+
+import (
+	"math"
+	"math/rand"
+	"reflect"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestReviewBudgetForDeck(t *testing.T) {
+	scheduler := createDefaultScheduler()
+	cards := []Card{
+		NewCard(1),
+		NewCard(2),
+		NewCard(3),
+		{CardID: 4, Interval: 3 * dayDuration, Stability: 3, Difficulty: 6, State: Review},
+		{CardID: 5, Interval: 20 * dayDuration, Stability: 20, Difficulty: 4, State: Review},
+	}
+
+	low := scheduler.ReviewBudgetForDeck(cards, 0.8, 30)
+	high := scheduler.ReviewBudgetForDeck(cards, 0.95, 30)
+
+	if low <= 0 {
+		t.Errorf("Expected a positive review budget, but got %d", low)
+	}
+	if high <= low {
+		t.Errorf("Expected higher target to require more reviews, but got %d for 0.95 and %d for 0.8", high, low)
+	}
+	if budget := scheduler.ReviewBudgetForDeck(cards, 0, 30); budget != 0 {
+		t.Errorf("Expected zero budget for zero target, but got %d", budget)
+	}
+	if budget := scheduler.ReviewBudgetForDeck(nil, 0.9, 30); budget != 0 {
+		t.Errorf("Expected zero budget for an empty deck, but got %d", budget)
+	}
+}
+
+func TestSimulateSkip(t *testing.T) {
+	scheduler := createDefaultScheduler()
+	today := time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC)
+	tomorrow := today.AddDate(0, 0, 1)
+
+	cards := []Card{
+		{CardID: 1, State: Review, Stability: 3, Interval: 3 * dayDuration, LastReview: today.AddDate(0, 0, -3)},
+		{CardID: 2, State: Review, Stability: 5, Interval: 5 * dayDuration, LastReview: today.AddDate(0, 0, -5).Add(8 * time.Hour)},
+		{CardID: 3, State: Review, Stability: 9, Interval: 9 * dayDuration, LastReview: today.AddDate(0, 0, -10)},
+		{CardID: 4, State: Review, Stability: 4, Interval: 4 * dayDuration, LastReview: today.AddDate(0, 0, -3)},
+		NewCard(5),
+	}
+
+	dueTomorrowWithoutSkip := 0
+	dueTomorrowAfterSkip := 0
+	for _, card := range cards {
+		if card.State == New {
+			continue
+		}
+		if startOfDay(card.Due()).Equal(startOfDay(tomorrow)) {
+			dueTomorrowWithoutSkip++
+		}
+		if card.Due().Before(startOfDay(tomorrow).AddDate(0, 0, 1)) {
+			dueTomorrowAfterSkip++
+		}
+	}
+
+	extra := scheduler.SimulateSkip(cards, today, tomorrow)
+	if extra != 3 {
+		t.Errorf("Expected 3 extra reviews, but got %d", extra)
+	}
+	if dueTomorrowWithoutSkip+extra != dueTomorrowAfterSkip {
+		t.Errorf("Expected %d + %d reviews tomorrow after skipping, but got %d", dueTomorrowWithoutSkip, extra, dueTomorrowAfterSkip)
+	}
+
+	if extra := scheduler.SimulateSkip(cards, today, today.Add(time.Hour)); extra != 0 {
+		t.Errorf("Expected no extra reviews when the next day is the skipped day, but got %d", extra)
+	}
+}
+
+func TestForgettingIndex(t *testing.T) {
+	scheduler := createDefaultScheduler()
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	cards := []Card{
+		{CardID: 1, State: Review, Stability: 2, Difficulty: 7, Interval: 2 * dayDuration, LastReview: now.AddDate(0, 0, -10)},
+		{CardID: 2, State: Review, Stability: 40, Difficulty: 3, Interval: 40 * dayDuration, LastReview: now.AddDate(0, 0, -5)},
+		{CardID: 3, State: Learning, Stability: 1, Difficulty: 5, Interval: 10 * time.Minute, LastReview: now.Add(-time.Hour)},
+		NewCard(4),
+	}
+
+	index := scheduler.ForgettingIndex(cards, now)
+	score := scheduler.DeckRetentionScore(cards, now)
+	if math.Abs(index-(1-score)) > 1e-12 {
+		t.Errorf("Expected forgetting index %v, but got %v", 1-score, index)
+	}
+	if index <= 0 || index >= 1 {
+		t.Errorf("Expected forgetting index in (0, 1), but got %v", index)
+	}
+
+	if index := scheduler.ForgettingIndex([]Card{NewCard(1)}, now); index != 0 {
+		t.Errorf("Expected forgetting index 0 for an unreviewed deck, but got %v", index)
+	}
+}
+
+func TestCardsToReset(t *testing.T) {
+	scheduler := createDefaultScheduler()
+	now := time.Date(2025, 9, 1, 12, 0, 0, 0, time.UTC)
+	cards := []Card{
+		{CardID: 1, State: Review, Stability: 1.5, Difficulty: 8, Interval: 2 * dayDuration, LastReview: now.AddDate(0, -6, 0)},
+		{CardID: 2, State: Review, Stability: 1.5, Difficulty: 8, Interval: 2 * dayDuration, LastReview: now.AddDate(0, 0, -1)},
+		NewCard(3),
+	}
+
+	reset := scheduler.CardsToReset(cards, now, 0.5)
+	if len(reset) != 1 || reset[0].CardID != 1 {
+		t.Errorf("Expected only card 1 to be reset, but got %+v", reset)
+	}
+}
+
+func TestPreTripCramList(t *testing.T) {
+	scheduler := createDefaultScheduler()
+	tripStart := time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC)
+	tripEnd := tripStart.AddDate(0, 0, 14)
+	cards := []Card{
+		{CardID: 1, State: Review, Stability: 300, Difficulty: 4, Interval: 270 * dayDuration, LastReview: tripStart.AddDate(0, 0, -10)},
+		{CardID: 2, State: Review, Stability: 5, Difficulty: 6, Interval: 5 * dayDuration, LastReview: tripStart.AddDate(0, 0, -1)},
+		{CardID: 3, State: Review, Stability: 60, Difficulty: 5, Interval: 55 * dayDuration, LastReview: tripStart.AddDate(0, 0, -150)},
+		{CardID: 4, State: Relearning, Stability: 0.5, Difficulty: 9, Interval: 10 * time.Minute, LastReview: tripStart.Add(-time.Hour)},
+		NewCard(5),
+	}
+
+	cram := scheduler.PreTripCramList(cards, tripStart, tripEnd, 0.85)
+	var ids []int64
+	for _, card := range cram {
+		ids = append(ids, card.CardID)
+	}
+	if !slices.Equal(ids, []int64{2, 3, 4}) {
+		t.Errorf("Expected cards [2 3 4] to need cramming, but got %v", ids)
+	}
+}
+
+func TestNextAvailableDue(t *testing.T) {
+	scheduler := createDefaultScheduler()
+	weekdays := [7]bool{false, true, true, true, true, true, false}
+	// Saturday 2025-06-14 at 10:00.
+	saturday := time.Date(2025, 6, 14, 10, 0, 0, 0, time.UTC)
+
+	longCard := Card{CardID: 1, State: Review, Stability: 30, Difficulty: 5, Interval: 30 * dayDuration, LastReview: saturday.AddDate(0, 0, -30)}
+	got := scheduler.NextAvailableDue(longCard, longCard.LastReview, weekdays, time.UTC)
+	if expected := saturday.AddDate(0, 0, -1); !got.Equal(expected) {
+		t.Errorf("Expected the weekend due date to move to Friday %v, but got %v", expected, got)
+	}
+
+	// A one-day interval has no fuzz band, so the card waits for Monday.
+	shortCard := Card{CardID: 2, State: Review, Stability: 1, Difficulty: 5, Interval: dayDuration, LastReview: saturday.AddDate(0, 0, -1)}
+	got = scheduler.NextAvailableDue(shortCard, shortCard.LastReview, weekdays, time.UTC)
+	if expected := saturday.AddDate(0, 0, 2); !got.Equal(expected) {
+		t.Errorf("Expected the weekend due date to move to Monday %v, but got %v", expected, got)
+	}
+
+	// Sunday due with a fuzz band that cannot reach back to Friday.
+	sunday := saturday.AddDate(0, 0, 1)
+	threeDay := Card{CardID: 3, State: Review, Stability: 3, Difficulty: 5, Interval: 3 * dayDuration, LastReview: sunday.AddDate(0, 0, -3)}
+	got = scheduler.NextAvailableDue(threeDay, threeDay.LastReview, weekdays, time.UTC)
+	if expected := sunday.AddDate(0, 0, 1); !got.Equal(expected) {
+		t.Errorf("Expected %v, but got %v", expected, got)
+	}
+
+	weekdayDue := Card{CardID: 4, State: Review, Stability: 10, Difficulty: 5, Interval: 10 * dayDuration, LastReview: saturday.AddDate(0, 0, -8)}
+	if got := scheduler.NextAvailableDue(weekdayDue, weekdayDue.LastReview, weekdays, time.UTC); !got.Equal(weekdayDue.Due()) {
+		t.Errorf("Expected an available due date to stay at %v, but got %v", weekdayDue.Due(), got)
+	}
+}
+
+func TestSelectForMinForgetting(t *testing.T) {
+	scheduler := createDefaultScheduler()
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	random := rand.New(rand.NewSource(3))
+
+	var cards []Card
+	for i := range 200 {
+		stability := 1 + random.Float64()*60
+		ago := time.Duration(random.Float64() * 90 * float64(dayDuration))
+		cards = append(cards, Card{CardID: int64(i + 1), State: Review, Stability: stability, Difficulty: 5, Interval: dayDuration, LastReview: now.Add(-ago)})
+	}
+	cards = append(cards, NewCard(999))
+
+	reviewAll := func(selected []Card) float64 {
+		ids := make(map[int64]bool)
+		for _, card := range selected {
+			ids[card.CardID] = true
+		}
+		after := slices.Clone(cards)
+		for i := range after {
+			if ids[after[i].CardID] {
+				after[i].LastReview = now
+			}
+		}
+		return scheduler.ForgettingIndex(after, now)
+	}
+
+	selected := scheduler.SelectForMinForgetting(cards, now, 20)
+	if len(selected) != 20 {
+		t.Fatalf("Expected 20 cards, but got %d", len(selected))
+	}
+	greedy := reviewAll(selected)
+	for trial := range 10 {
+		randomPick := make([]Card, 0, 20)
+		for _, i := range random.Perm(200)[:20] {
+			randomPick = append(randomPick, cards[i])
+		}
+		if randomIndex := reviewAll(randomPick); greedy >= randomIndex {
+			t.Errorf("Trial %d: expected greedy selection to beat random, but got %v vs %v", trial, greedy, randomIndex)
+		}
+	}
+
+	if got := scheduler.SelectForMinForgetting(cards[:3], now, 10); len(got) != 3 {
+		t.Errorf("Expected all 3 cards when maxCards exceeds the deck, but got %d", len(got))
+	}
+	if got := scheduler.SelectForMinForgetting(cards, now, 0); len(got) != 0 {
+		t.Errorf("Expected no cards for maxCards 0, but got %d", len(got))
+	}
+}
+
+func TestForecastHeatmap(t *testing.T) {
+	scheduler := createDefaultScheduler()
+	// Wednesday 2025-06-11.
+	now := time.Date(2025, 6, 11, 15, 0, 0, 0, time.UTC)
+	dueOn := func(id int64, due time.Time) Card {
+		return Card{CardID: id, State: Review, Stability: 5, Difficulty: 5, Interval: 5 * dayDuration, LastReview: due.Add(-5 * dayDuration)}
+	}
+	cards := []Card{
+		dueOn(1, now.Add(2*time.Hour)),
+		dueOn(2, now.AddDate(0, 0, -3)),
+		dueOn(3, time.Date(2025, 6, 14, 8, 0, 0, 0, time.UTC)),
+		dueOn(4, time.Date(2025, 6, 15, 8, 0, 0, 0, time.UTC)),
+		dueOn(5, time.Date(2025, 6, 23, 8, 0, 0, 0, time.UTC)),
+		dueOn(6, time.Date(2025, 6, 23, 20, 0, 0, 0, time.UTC)),
+		dueOn(7, time.Date(2025, 7, 30, 8, 0, 0, 0, time.UTC)),
+		NewCard(8),
+	}
+
+	grid := scheduler.ForecastHeatmap(cards, now, 3)
+	expected := make([][7]int, 3)
+	expected[0][time.Wednesday] = 2
+	expected[0][time.Saturday] = 1
+	expected[1][time.Sunday] = 1
+	expected[2][time.Monday] = 2
+	if !reflect.DeepEqual(grid, expected) {
+		t.Errorf("Expected %v, but got %v", expected, grid)
+	}
+	if grid := scheduler.ForecastHeatmap(cards, now, 0); grid != nil {
+		t.Errorf("Expected nil for zero weeks, but got %v", grid)
+	}
+}
+
+func TestRecommendedBatchSize(t *testing.T) {
+	scheduler := createDefaultScheduler()
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	var cards []Card
+	for i := range 100 {
+		lastReview := now.AddDate(0, 0, -10)
+		if i%4 == 0 {
+			lastReview = now
+		}
+		cards = append(cards, Card{CardID: int64(i + 1), State: Review, Stability: 5, Difficulty: 5, Interval: 5 * dayDuration, LastReview: lastReview})
+	}
+	cards = append(cards, NewCard(101))
+
+	short := scheduler.RecommendedBatchSize(cards, now, 5, 10)
+	long := scheduler.RecommendedBatchSize(cards, now, 10, 10)
+	if short != 30 || long != 60 {
+		t.Errorf("Expected 30 and 60 cards, but got %d and %d", short, long)
+	}
+	if got := scheduler.RecommendedBatchSize(cards, now, 120, 10); got != 75 {
+		t.Errorf("Expected the batch to be capped at the 75 due cards, but got %d", got)
+	}
+	if got := scheduler.RecommendedBatchSize(cards, now, 10, 0); got != 0 {
+		t.Errorf("Expected 0 for an invalid per-card time, but got %d", got)
+	}
+}
+
+func TestRecoveryPlan(t *testing.T) {
+	scheduler := createDefaultScheduler()
+	now := time.Date(2025, 6, 1, 9, 0, 0, 0, time.UTC)
+	lastReview := now.AddDate(0, 0, -60)
+
+	var cards []Card
+	for _, id := range []int64{7, 3, 10, 1, 5, 9, 2, 8, 4, 6} {
+		cards = append(cards, Card{CardID: id, State: Review, Stability: float64(id), Difficulty: 5, Interval: 10 * dayDuration, LastReview: lastReview})
+	}
+	cards = append(cards,
+		NewCard(11),
+		Card{CardID: 12, State: Review, Stability: 0.5, Difficulty: 5, Interval: 90 * dayDuration, LastReview: lastReview},
+	)
+
+	expected := map[int][]int64{
+		0: {1, 2, 3},
+		1: {4, 5, 6},
+		2: {7, 8, 9},
+		3: {10},
+	}
+	if actual := scheduler.RecoveryPlan(cards, now, 3); !reflect.DeepEqual(expected, actual) {
+		t.Errorf("Expected %v, but got %v", expected, actual)
+	}
+	if actual := scheduler.RecoveryPlan(cards, now, 0); actual != nil {
+		t.Errorf("Expected nil for a non-positive daily limit, but got %v", actual)
+	}
+}
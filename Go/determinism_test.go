@@ -0,0 +1,71 @@
+package fsrs
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestBulkOperationsRepeatWithSameSeed(t *testing.T) {
+	config := DefaultSchedulerConfig()
+	card := Card{CardID: 1, State: Review, Stability: 8, Difficulty: 5, Interval: 8 * dayDuration}
+
+	mean1, p901 := createDefaultScheduler().ExpectedReviews(card, 365*dayDuration, NewDefaultRatingModel(), 9, 200)
+	mean2, p902 := createDefaultScheduler().ExpectedReviews(card, 365*dayDuration, NewDefaultRatingModel(), 9, 200)
+	if mean1 != mean2 || p901 != p902 {
+		t.Errorf("Expected ExpectedReviews to repeat, but got %v/%v and %v/%v", mean1, p901, mean2, p902)
+	}
+
+	a, _ := PlanNewCardIntroduction(200, 40, config, nil, 4)
+	b, _ := PlanNewCardIntroduction(200, 40, config, nil, 4)
+	if !reflect.DeepEqual(a, b) {
+		t.Errorf("Expected PlanNewCardIntroduction to repeat, but got %v and %v", a, b)
+	}
+
+	recall := func(retrievability float64, rng *rand.Rand) Rating {
+		if retrievability > rng.Float64() {
+			return Good
+		}
+		return Again
+	}
+	simulate := func() SimResult {
+		scheduler, _ := NewScheduler(config, rand.New(rand.NewSource(6)))
+		return scheduler.SimulateDeckOverTime(5, 60, recall)
+	}
+	if a, b := simulate(), simulate(); !reflect.DeepEqual(a, b) {
+		t.Errorf("Expected SimulateDeckOverTime to repeat with the same source")
+	}
+}
+
+func TestApplyReviewStreamOrderIndependent(t *testing.T) {
+	start := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+	cards := make(map[int64]Card)
+	var reviews []TimedReview
+	for id := int64(1); id <= 50; id++ {
+		cards[id] = NewCard(id)
+		at := start
+		for _, rating := range []Rating{Good, Good, Good, Hard, Good, Easy} {
+			reviews = append(reviews, TimedReview{CardID: id, Rating: rating, ReviewTime: at})
+			at = at.Add(time.Duration(id%3+1) * 9 * dayDuration)
+		}
+	}
+
+	apply := func(reviews []TimedReview) map[int64]Card {
+		scheduler, _ := NewScheduler(DefaultSchedulerConfig(), rand.New(rand.NewSource(8)))
+		updated, _, errs := scheduler.ApplyReviewStream(cards, reviews)
+		if len(errs) != 0 {
+			t.Fatalf("Unexpected errors: %v", errs)
+		}
+		return updated
+	}
+
+	expected := apply(reviews)
+	shuffled := append([]TimedReview(nil), reviews...)
+	rand.New(rand.NewSource(2)).Shuffle(len(shuffled), func(i, j int) {
+		shuffled[i], shuffled[j] = shuffled[j], shuffled[i]
+	})
+	if got := apply(shuffled); !reflect.DeepEqual(expected, got) {
+		t.Errorf("Expected the same schedule for a permuted stream")
+	}
+}
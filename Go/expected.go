@@ -0,0 +1,78 @@
+package fsrs
+
+import (
+	"math"
+	"math/rand"
+	"slices"
+	"time"
+)
+
+const (
+	maxExpectedReviewsTrials   = 10000
+	maxExpectedReviewsPerTrial = 10000
+)
+
+// ExpectedReviews simulates the card over horizon, measured from its last
+// review, and returns the mean and 90th percentile of the review count.
+// Trials are capped at 10000. A nil model uses the all-Good path, which is
+// deterministic, so mean and p90 are equal.
+func (s *Scheduler) ExpectedReviews(card Card, horizon time.Duration, model RatingModel, seed int64, trials int) (mean, p90 float64) {
+	if model == nil {
+		count := float64(s.countReviews(card, horizon, func(Card, time.Duration) Rating { return Good }))
+		return count, count
+	}
+
+	trials = min(max(trials, 1), maxExpectedReviewsTrials)
+	rng := rand.New(rand.NewSource(seed))
+	counts := make([]int, trials)
+	total := 0
+	for i := range counts {
+		counts[i] = s.countReviews(card, horizon, func(c Card, elapsed time.Duration) Rating {
+			if c.State == New {
+				return sampleRating(model.FirstRating(), rng.Float64())
+			}
+			return sampleRating(model.Rating(s.retrievability(c.Stability, elapsed)), rng.Float64())
+		})
+		total += counts[i]
+	}
+
+	slices.Sort(counts)
+	p90Index := int(math.Ceil(0.9*float64(trials))) - 1
+	return float64(total) / float64(trials), float64(counts[p90Index])
+}
+
+func (s *Scheduler) countReviews(card Card, horizon time.Duration, rate func(Card, time.Duration) Rating) int {
+	at := card.Interval
+	reviews := 0
+	for at <= horizon && reviews < maxExpectedReviewsPerTrial {
+		rating := rate(card, card.Interval)
+		card = s.previewCard(card, rating, card.Interval)
+		reviews++
+		at += card.Interval
+	}
+	return reviews
+}
+
+// ExpectedNextInterval returns the mean of the unfuzzed intervals the four
+// ratings would schedule, weighted by the model's rating probabilities.
+// New and Learning cards use the model's first-rating distribution, other
+// cards its distribution at the card's retrievability after
+// reviewInterval. A nil model uses the Good interval.
+func (s *Scheduler) ExpectedNextInterval(card Card, reviewInterval time.Duration, model RatingModel) time.Duration {
+	if model == nil {
+		return s.previewCard(card, Good, reviewInterval).Interval
+	}
+
+	var probabilities [4]float64
+	if card.State == New || card.State == Learning {
+		probabilities = model.FirstRating()
+	} else {
+		probabilities = model.Rating(s.retrievability(card.Stability, reviewInterval))
+	}
+
+	expected := 0.0
+	for i, rating := range Ratings() {
+		expected += probabilities[i] * float64(s.previewCard(card, rating, reviewInterval).Interval)
+	}
+	return time.Duration(expected)
+}
@@ -0,0 +1,86 @@
+package fsrs
+
+import (
+	"testing"
+	"time"
+)
+
+func TestExpectedReviewsAllGood(t *testing.T) {
+	scheduler := createDefaultScheduler()
+	card := Card{CardID: 1, Interval: 5 * dayDuration, Stability: 5, Difficulty: 5, State: Review}
+
+	mean, p90 := scheduler.ExpectedReviews(card, 365*dayDuration, nil, 1, 100)
+	if mean != p90 {
+		t.Errorf("Expected deterministic fast mode, but got mean %v and p90 %v", mean, p90)
+	}
+
+	expected := 0
+	at := card.Interval
+	for at <= 365*dayDuration {
+		card = scheduler.previewCard(card, Good, card.Interval)
+		expected++
+		at += card.Interval
+	}
+	if mean != float64(expected) {
+		t.Errorf("Expected %d reviews, but got %v", expected, mean)
+	}
+}
+
+func TestExpectedReviewsMonteCarlo(t *testing.T) {
+	scheduler := createDefaultScheduler()
+	model := NewDefaultRatingModel()
+	weak := Card{CardID: 1, Interval: 2 * dayDuration, Stability: 2, Difficulty: 8, State: Review}
+	strong := Card{CardID: 2, Interval: 60 * dayDuration, Stability: 60, Difficulty: 3, State: Review}
+
+	weakMean, weakP90 := scheduler.ExpectedReviews(weak, 365*dayDuration, model, 7, 500)
+	strongMean, _ := scheduler.ExpectedReviews(strong, 365*dayDuration, model, 7, 500)
+
+	if weakP90 < weakMean {
+		t.Errorf("Expected p90 %v to be at least the mean %v", weakP90, weakMean)
+	}
+	if strongMean >= weakMean {
+		t.Errorf("Expected strong card to need fewer reviews, but got %v vs %v", strongMean, weakMean)
+	}
+
+	againMean, againP90 := scheduler.ExpectedReviews(weak, 365*dayDuration, model, 7, 500)
+	if againMean != weakMean || againP90 != weakP90 {
+		t.Errorf("Expected identical results for the same seed")
+	}
+
+	newMean, _ := scheduler.ExpectedReviews(NewCard(3), 30*dayDuration, model, 7, 200)
+	if newMean < 2 {
+		t.Errorf("Expected a new card to need several reviews in its first month, but got %v", newMean)
+	}
+}
+
+func TestExpectedNextInterval(t *testing.T) {
+	config := DefaultSchedulerConfig()
+	config.EnableFuzzing = false
+	scheduler, _ := NewScheduler(config, testRand)
+	model := NewDefaultRatingModel()
+
+	review := Card{CardID: 1, Interval: 10 * dayDuration, Stability: 10, Difficulty: 5, State: Review}
+	probabilities := model.Rating(scheduler.retrievability(review.Stability, 12*dayDuration))
+	expected := 0.0
+	for i, rating := range Ratings() {
+		expected += probabilities[i] * float64(scheduler.ReviewCard(review, rating, 12*dayDuration).Interval)
+	}
+	if actual := scheduler.ExpectedNextInterval(review, 12*dayDuration, model); actual != time.Duration(expected) {
+		t.Errorf("Expected %v, but got %v", time.Duration(expected), actual)
+	}
+	again := scheduler.ReviewCard(review, Again, 12*dayDuration).Interval
+	good := scheduler.ReviewCard(review, Good, 12*dayDuration).Interval
+	easy := scheduler.ReviewCard(review, Easy, 12*dayDuration).Interval
+	if actual := scheduler.ExpectedNextInterval(review, 12*dayDuration, model); actual <= again || actual >= easy {
+		t.Errorf("Expected an interval between %v and %v, but got %v", again, easy, actual)
+	}
+
+	allGood := DefaultRatingModel{FirstRatings: [4]float64{0, 0, 1, 0}, RecallRatings: [3]float64{0, 1, 0}}
+	card := NewCard(2)
+	if actual, expected := scheduler.ExpectedNextInterval(card, 0, allGood), scheduler.ReviewCard(card, Good, 0).Interval; actual != expected {
+		t.Errorf("Expected the Good interval %v for a New card, but got %v", expected, actual)
+	}
+	if actual := scheduler.ExpectedNextInterval(review, 12*dayDuration, nil); actual != good {
+		t.Errorf("Expected the Good interval %v for a nil model, but got %v", good, actual)
+	}
+}
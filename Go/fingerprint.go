@@ -0,0 +1,25 @@
+package fsrs
+
+import (
+	"encoding/binary"
+	"hash/fnv"
+	"math"
+)
+
+// Fingerprint hashes the fields that affect scheduling, for cache
+// invalidation. It is the 64-bit FNV-1a hash of five little-endian int64
+// values: State, Step, Stability and Difficulty each multiplied by 1e4 and
+// rounded, and Interval in nanoseconds. Other fields, such as CardID and
+// LastReview, are ignored. The algorithm only changes with a major version.
+func (c Card) Fingerprint() uint64 {
+	var buf [40]byte
+	binary.LittleEndian.PutUint64(buf[0:], uint64(c.State))
+	binary.LittleEndian.PutUint64(buf[8:], uint64(c.Step))
+	binary.LittleEndian.PutUint64(buf[16:], uint64(int64(math.Round(c.Stability*1e4))))
+	binary.LittleEndian.PutUint64(buf[24:], uint64(int64(math.Round(c.Difficulty*1e4))))
+	binary.LittleEndian.PutUint64(buf[32:], uint64(c.Interval))
+
+	h := fnv.New64a()
+	h.Write(buf[:])
+	return h.Sum64()
+}
@@ -0,0 +1,51 @@
+package fsrs
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFingerprintGolden(t *testing.T) {
+	cases := []struct {
+		card     Card
+		expected uint64
+	}{
+		{NewCard(1), 0x40d69e0cf0f65c45},
+		{Card{State: Review, Stability: 12.3456, Difficulty: 5.4321, Interval: 12 * dayDuration}, 0xd0a38a9d58b0c1ff},
+		{Card{State: Learning, Step: 1, Stability: 2.3065, Difficulty: 2.1181, Interval: 10 * time.Minute}, 0x496ea695dabc8d39},
+	}
+	for _, c := range cases {
+		if got := c.card.Fingerprint(); got != c.expected {
+			t.Errorf("Expected %#x for %+v, but got %#x", c.expected, c.card, got)
+		}
+	}
+}
+
+func TestFingerprintFields(t *testing.T) {
+	card := Card{CardID: 1, State: Review, Stability: 12.3456, Difficulty: 5.4321, Interval: 12 * dayDuration, LastReview: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)}
+	fingerprint := card.Fingerprint()
+
+	irrelevant := card
+	irrelevant.CardID = 2
+	irrelevant.LastReview = irrelevant.LastReview.AddDate(0, 1, 0)
+	irrelevant.Reps = 7
+	irrelevant.Stability += 1e-6
+	if irrelevant.Fingerprint() != fingerprint {
+		t.Errorf("Expected ID, review time, reps and sub-quantum changes not to affect the fingerprint")
+	}
+
+	changes := []func(*Card){
+		func(c *Card) { c.State = Relearning },
+		func(c *Card) { c.Step = 1 },
+		func(c *Card) { c.Stability += 1e-3 },
+		func(c *Card) { c.Difficulty -= 1e-3 },
+		func(c *Card) { c.Interval += time.Minute },
+	}
+	for i, change := range changes {
+		changed := card
+		change(&changed)
+		if changed.Fingerprint() == fingerprint {
+			t.Errorf("Change %d: expected the fingerprint to change", i)
+		}
+	}
+}
@@ -1,10 +1,12 @@
 package fsrs
 
 import (
+	"cmp"
 	"errors"
 	"fmt"
 	"math"
 	"math/rand"
+	"slices"
 	"time"
 )
 
@@ -28,6 +30,33 @@ const (
 	Relearning State = 3
 )
 
+func (s State) String() string {
+	switch s {
+	case New:
+		return "New"
+	case Learning:
+		return "Learning"
+	case Review:
+		return "Review"
+	case Relearning:
+		return "Relearning"
+	default:
+		return fmt.Sprintf("State(%d)", int(s))
+	}
+}
+
+// Ratings returns all ratings in canonical order, Again to Easy.
+// APIs that report per-rating results return them in this order.
+func Ratings() []Rating {
+	return []Rating{Again, Hard, Good, Easy}
+}
+
+// States returns all states in canonical order, New to Relearning.
+// APIs that report per-state results return them in this order.
+func States() []State {
+	return []State{New, Learning, Review, Relearning}
+}
+
 type Card struct {
 	CardID     int64
 	Interval   time.Duration
@@ -35,6 +64,28 @@ type Card struct {
 	Difficulty float64
 	State      State
 	Step       int
+	LastReview time.Time
+
+	ConsecutiveHard int
+
+	// Reps counts the reviews applied to the card.
+	Reps int
+
+	// ConsecutiveDefers counts Defer calls since the last review.
+	ConsecutiveDefers int
+
+	// DeferredBy is the total postponement added by Defer since the last
+	// review. It moves Due but not Interval, which the memory model reads.
+	DeferredBy time.Duration
+
+	// SameDayUpdates counts the short-term reviews that changed stability
+	// since the last review a day or more apart.
+	SameDayUpdates int
+
+	// ParamsID names the parameter set the card's memory state was
+	// computed with, for routing by MultiScheduler. Empty means the
+	// default set.
+	ParamsID string
 }
 
 func NewCard(cardID int64) Card {
@@ -43,13 +94,115 @@ func NewCard(cardID int64) Card {
 	}
 }
 
+func (c Card) Due() time.Time {
+	return c.LastReview.Add(c.Interval + c.DeferredBy)
+}
+
+// LogKind distinguishes graded reviews from events that leave the memory
+// state untouched.
+type LogKind int
+
+const (
+	LogReview LogKind = 0
+	LogDefer  LogKind = 1
+)
+
+type ReviewLog struct {
+	CardID     int64
+	Kind       LogKind
+	Rating     Rating
+	ReviewTime time.Time
+	State      State
+
+	ScheduledInterval time.Duration
+	Elapsed           time.Duration
+	Stability         float64
+	Difficulty        float64
+
+	// SkewAdjustment is how far ReviewAt found ReviewTime before the card's
+	// LastReview, within ClockSkewTolerance, and counted as zero elapsed.
+	SkewAdjustment time.Duration
+
+	Meta map[string]string
+}
+
+// SortCardsByID returns a copy of cards ordered by CardID. Cards sharing
+// a CardID keep their relative order. APIs that report per-card results
+// in a slice return them in this order.
+func SortCardsByID(cards []Card) []Card {
+	sorted := slices.Clone(cards)
+	slices.SortStableFunc(sorted, func(a, b Card) int {
+		return cmp.Compare(a.CardID, b.CardID)
+	})
+	return sorted
+}
+
+type AgainStepBehavior int
+
+const (
+	ResetToFirst AgainStepBehavior = 0
+	StepBack     AgainStepBehavior = 1
+)
+
 type SchedulerConfig struct {
-	Parameters       []float64
+	// Parameters holds 17, 19 or 21 weights. Nil or empty means the
+	// defaults of the latest supported version.
+	Parameters []float64
+	// ParametersFitted records when Parameters were last optimized. Zero
+	// means unknown, and DeckHealth then leaves parameter staleness ok.
+	ParametersFitted time.Time
 	DesiredRetention float64
 	LearningSteps    []time.Duration
 	RelearningSteps  []time.Duration
 	MaximumInterval  int
 	EnableFuzzing    bool
+
+	// IntervalScale multiplies every Review interval before it is clamped.
+	// Zero is treated as 1.
+	IntervalScale float64
+
+	// ClockSkewTolerance is how far a review may precede the card's
+	// LastReview in ReviewAt and still be treated as a same-moment review.
+	ClockSkewTolerance time.Duration
+
+	// OverrideDecay replaces the decay learned in w[20] for both interval
+	// and retrievability calculations. It must lie in [0.1, 0.8].
+	OverrideDecay *float64
+
+	// RetentionByDifficulty, when set, replaces DesiredRetention as the
+	// retention Review intervals aim for, chosen by the card's difficulty.
+	// It is called with difficulties in [1, 10] and must return a value in
+	// (0, 1) for each; NewScheduler samples it every 0.1 to check. Helpers
+	// with no card difficulty to pass, such as CalculateNextReviewInterval,
+	// still use DesiredRetention.
+	RetentionByDifficulty func(difficulty float64) float64
+
+	// AgainStepBehavior chooses the step a Learning or Relearning card
+	// returns to when rated Again.
+	AgainStepBehavior AgainStepBehavior
+
+	// MaxConsecutiveDefers caps how many times Defer may postpone a card
+	// between reviews. Zero means no cap.
+	MaxConsecutiveDefers int
+
+	// MaxSameDayUpdates caps how many short-term reviews in a row may change
+	// stability and difficulty; later ones only move the card through its
+	// steps. Zero means no cap.
+	MaxSameDayUpdates int
+
+	// RoundDayScaleSteps rounds the elapsed time to whole days when choosing
+	// between the short- and long-term stability update for Learning and
+	// Relearning cards on steps of a day or more. Such cards are due at
+	// exactly a day, so under the strict 24-hour cutoff an on-time review
+	// takes a different update depending on whether it came a few minutes
+	// early or late. False keeps the strict cutoff of the reference
+	// implementation and the other ports.
+	RoundDayScaleSteps bool
+
+	// HardGraduationLimit graduates a card rated Hard this many times in a
+	// row on its final step. Zero keeps repeating the final step, relying on
+	// an eventual Good or Easy to graduate.
+	HardGraduationLimit int
 }
 
 func DefaultSchedulerConfig() SchedulerConfig {
@@ -61,9 +214,15 @@ func DefaultSchedulerConfig() SchedulerConfig {
 		RelearningSteps:  []time.Duration{10 * time.Minute},
 		MaximumInterval:  36500,
 		EnableFuzzing:    true,
+		IntervalScale:    1.0,
 	}
 }
 
+const (
+	minOverrideDecay = 0.1
+	maxOverrideDecay = 0.8
+)
+
 type Scheduler struct {
 	config SchedulerConfig
 	random *rand.Rand
@@ -72,12 +231,43 @@ type Scheduler struct {
 	factor float64
 }
 
+// NewScheduler validates config and precomputes the forgetting curve. It is
+// cheap enough, about one allocation and under a microsecond, to call once
+// per request in a stateless server. It never writes to config, so one
+// config may be shared by concurrent callers. *rand.Rand is not safe for
+// concurrent use, so a Scheduler with a non-nil random must not be used
+// from multiple goroutines; one with a nil random may be.
 func NewScheduler(config SchedulerConfig, random *rand.Rand) (*Scheduler, error) {
 	w, err := checkAndFillParameters(config.Parameters)
 	if err != nil {
 		return nil, err
 	}
+	if err := checkRetentionByDifficulty(config.RetentionByDifficulty); err != nil {
+		return nil, err
+	}
+	if config.IntervalScale < 0 || math.IsNaN(config.IntervalScale) || math.IsInf(config.IntervalScale, 0) {
+		return nil, fmt.Errorf("invalid interval scale %v", config.IntervalScale)
+	}
+	if config.AgainStepBehavior != ResetToFirst && config.AgainStepBehavior != StepBack {
+		return nil, fmt.Errorf("invalid again step behavior %d", config.AgainStepBehavior)
+	}
+	if config.MaxConsecutiveDefers < 0 {
+		return nil, fmt.Errorf("invalid maximum consecutive defers %d", config.MaxConsecutiveDefers)
+	}
+	if config.MaxSameDayUpdates < 0 {
+		return nil, fmt.Errorf("invalid maximum same-day updates %d", config.MaxSameDayUpdates)
+	}
+	if config.HardGraduationLimit < 0 {
+		return nil, fmt.Errorf("invalid hard graduation limit %d", config.HardGraduationLimit)
+	}
 	decay := -w[20]
+	if config.OverrideDecay != nil {
+		d := *config.OverrideDecay
+		if math.IsNaN(d) || d < minOverrideDecay || d > maxOverrideDecay {
+			return nil, fmt.Errorf("invalid decay override %v, must be between %v and %v", d, minOverrideDecay, maxOverrideDecay)
+		}
+		decay = -d
+	}
 	factor := math.Pow(0.9, 1.0/decay) - 1.0
 	return &Scheduler{
 		config: config,
@@ -88,11 +278,24 @@ func NewScheduler(config SchedulerConfig, random *rand.Rand) (*Scheduler, error)
 	}, nil
 }
 
+// NewDeterministicScheduler returns a scheduler without a random source.
+// It fails when the configuration enables a feature that needs randomness.
+func NewDeterministicScheduler(config SchedulerConfig) (*Scheduler, error) {
+	if config.EnableFuzzing {
+		return nil, errors.New("deterministic scheduler requires fuzzing to be disabled")
+	}
+	return NewScheduler(config, nil)
+}
+
+// ReviewCard is Review without options, keeping only the card. Without
+// options Review fails only for a rating outside Again to Easy, and
+// ReviewCard then swallows the error and returns card unchanged.
 func (s *Scheduler) ReviewCard(card Card, rating Rating, reviewInterval time.Duration) Card {
-	reviewedCard := s.calculateInitialReviewedCard(card, rating, reviewInterval)
-	cardWithNextState := s.determineNextPhaseAndInterval(reviewedCard, rating)
-	finalCard := s.applyFuzzing(cardWithNextState)
-	return finalCard
+	result, err := s.Review(card, rating, reviewInterval)
+	if err != nil {
+		return card
+	}
+	return result.Card
 }
 
 func (s *Scheduler) calculateInitialReviewedCard(card Card, rating Rating, reviewInterval time.Duration) Card {
@@ -106,26 +309,50 @@ func (s *Scheduler) calculateInitialReviewedCard(card Card, rating Rating, revie
 		return card
 	}
 
-	newDifficulty := nextDifficulty(s.w, card.Difficulty, rating)
 	var newStability float64
-	if reviewInterval < dayDuration {
+	if s.isShortTermReview(card, reviewInterval) {
+		if limit := s.config.MaxSameDayUpdates; limit > 0 && card.SameDayUpdates >= limit {
+			return card
+		}
 		newStability = shortTermStability(s.w, card.Stability, rating)
+		card.SameDayUpdates++
 	} else {
 		newStability = s.getLongTermStability(card, rating, reviewInterval)
+		card.SameDayUpdates = 0
 	}
+	newDifficulty := nextDifficulty(s.w, card.Difficulty, rating)
 
 	card.Stability = newStability
 	card.Difficulty = newDifficulty
 	return card
 }
 
+// isShortTermReview chooses the short-term stability update for reviews
+// less than a day apart. With RoundDayScaleSteps, cards on day-scale
+// learning or relearning steps round the elapsed time to whole days
+// instead, a 12-hour cutoff.
+func (s *Scheduler) isShortTermReview(card Card, reviewInterval time.Duration) bool {
+	if s.config.RoundDayScaleSteps && (card.State == Learning || card.State == Relearning) && card.Interval >= dayDuration {
+		return math.Round(reviewInterval.Hours()/dayDuration.Hours()) < 1
+	}
+	return reviewInterval < dayDuration
+}
+
 func (s *Scheduler) getLongTermStability(card Card, rating Rating, reviewInterval time.Duration) float64 {
-	elapsedDays := math.Max(0.0, reviewInterval.Hours()/dayDuration.Hours())
-	retrievability := math.Pow(1.0+s.factor*elapsedDays/card.Stability, s.decay)
+	retrievability := s.retrievability(card.Stability, reviewInterval)
 	return nextStability(s.w, card.Difficulty, card.Stability, retrievability, rating)
 }
 
+func (s *Scheduler) retrievability(stability float64, elapsed time.Duration) float64 {
+	elapsedDays := math.Max(0.0, elapsed.Hours()/dayDuration.Hours())
+	return math.Pow(1.0+s.factor*elapsedDays/stability, s.decay)
+}
+
 func (s *Scheduler) determineNextPhaseAndInterval(reviewedCard Card, rating Rating) Card {
+	if rating != Hard {
+		reviewedCard.ConsecutiveHard = 0
+	}
+
 	switch reviewedCard.State {
 	case Learning:
 		return s.handleSteps(reviewedCard, rating, s.config.LearningSteps)
@@ -151,10 +378,19 @@ func (s *Scheduler) handleSteps(card Card, rating Rating, steps []time.Duration)
 	switch rating {
 	case Again:
 		card.State = Learning
-		card.Step = 0
-		card.Interval = steps[0]
+		if s.config.AgainStepBehavior == StepBack {
+			card.Step = max(0, min(card.Step, len(steps))-1)
+		} else {
+			card.Step = 0
+		}
+		card.Interval = steps[card.Step]
 		return card
 	case Hard:
+		card.ConsecutiveHard++
+		limit := s.config.HardGraduationLimit
+		if limit > 0 && card.Step == len(steps)-1 && card.ConsecutiveHard >= limit {
+			return s.toReviewState(card)
+		}
 		card.State = Learning
 		card.Interval = hardIntervalStep(card.Step, steps)
 		return card
@@ -173,15 +409,35 @@ func (s *Scheduler) handleSteps(card Card, rating Rating, steps []time.Duration)
 }
 
 func (s *Scheduler) toReviewState(card Card) Card {
-	interval := s.CalculateNextReviewInterval(card.Stability)
+	retention := s.desiredRetention(card.Difficulty)
+	interval := s.intervalForRetention(card.Stability, retention)
 	card.State = Review
 	card.Step = 0
 	card.Interval = interval
+	card.ConsecutiveHard = 0
 	return card
 }
 
+func (s *Scheduler) desiredRetention(difficulty float64) float64 {
+	if s.config.RetentionByDifficulty != nil {
+		return s.config.RetentionByDifficulty(difficulty)
+	}
+	return s.config.DesiredRetention
+}
+
 func (s *Scheduler) CalculateNextReviewInterval(stability float64) time.Duration {
-	return nextInterval(s.factor, s.config.DesiredRetention, s.decay, s.config.MaximumInterval, stability)
+	return s.intervalForRetention(stability, s.config.DesiredRetention)
+}
+
+func (s *Scheduler) intervalForRetention(stability, retention float64) time.Duration {
+	return nextInterval(s.factor, retention, s.decay, s.config.MaximumInterval, s.intervalScale(), stability)
+}
+
+func (s *Scheduler) intervalScale() float64 {
+	if s.config.IntervalScale == 0 {
+		return 1.0
+	}
+	return s.config.IntervalScale
 }
 
 func (s *Scheduler) applyFuzzing(card Card) Card {
@@ -193,11 +449,38 @@ func (s *Scheduler) applyFuzzing(card Card) Card {
 }
 
 func getFuzzedInterval(rand *rand.Rand, maxInterval int, interval time.Duration) time.Duration {
+	minDays, maxDays, ok := fuzzDayRange(interval)
+	if !ok {
+		return interval
+	}
+
+	fuzzed := rand.Intn(maxDays-minDays+1) + minDays
+	return clampFuzzedDays(maxInterval, fuzzed)
+}
+
+func (s *Scheduler) FuzzBounds(interval time.Duration) (time.Duration, time.Duration) {
+	minDays, maxDays, ok := fuzzDayRange(interval)
+	if !ok {
+		return interval, interval
+	}
+	return clampFuzzedDays(s.config.MaximumInterval, minDays), clampFuzzedDays(s.config.MaximumInterval, maxDays)
+}
+
+func fuzzDayRange(interval time.Duration) (int, int, bool) {
 	intervalDays := interval.Hours() / dayDuration.Hours()
 	if intervalDays < 2.5 {
-		return interval
+		return 0, 0, false
 	}
 
+	delta := fuzzDelta(intervalDays)
+	minDays := int(math.Round(intervalDays - delta))
+	maxDays := int(math.Round(intervalDays + delta))
+	return minDays, maxDays, true
+}
+
+// fuzzDelta returns the half-width in days of the fuzz band around an
+// interval.
+func fuzzDelta(intervalDays float64) float64 {
 	type fuzzRange struct {
 		start, end, factor float64
 	}
@@ -212,11 +495,10 @@ func getFuzzedInterval(rand *rand.Rand, maxInterval int, interval time.Duration)
 	for _, r := range ranges {
 		delta += r.factor * math.Max(0.0, math.Min(intervalDays, r.end)-r.start)
 	}
+	return delta
+}
 
-	minDays := int(math.Round(intervalDays - delta))
-	maxDays := int(math.Round(intervalDays + delta))
-	fuzzed := rand.Intn(maxDays-minDays+1) + minDays
-
+func clampFuzzedDays(maxInterval int, fuzzed int) time.Duration {
 	days := math.Min(float64(maxInterval), math.Max(2, float64(fuzzed)))
 	return time.Duration(days) * dayDuration
 }
@@ -241,15 +523,42 @@ func checkAndFillParameters(w []float64) ([]float64, error) {
 		}
 	}
 
+	// Full slice expressions make append copy, so a caller's spare
+	// capacity is never written to.
 	switch len(w) {
+	case 0:
+		return defaultParameters(FSRS6), nil
 	case 17:
-		return append(w, 0.0, 0.0, 0.0, 0.5), nil
+		return append(w[:17:17], 0.0, 0.0, 0.0, 0.5), nil
 	case 19:
-		return append(w, 0.0, 0.5), nil
+		return append(w[:19:19], 0.0, 0.5), nil
 	case 21:
 		return w, nil
 	default:
-		return nil, fmt.Errorf("invalid number of parameters. Supported: 17, 19, or 21, but got %d", len(w))
+		return nil, fmt.Errorf("invalid number of parameters. Supported: 0 for the defaults, 17, 19, or 21, but got %d", len(w))
+	}
+}
+
+func checkRetentionByDifficulty(retentionByDifficulty func(float64) float64) error {
+	if retentionByDifficulty == nil {
+		return nil
+	}
+	for i := 0; i <= 90; i++ {
+		d := minDifficulty + float64(i)*0.1
+		r := retentionByDifficulty(d)
+		if math.IsNaN(r) || r <= 0 || r >= 1 {
+			return fmt.Errorf("invalid retention by difficulty: got %v for difficulty %.1f, expected a value in (0, 1)", r, d)
+		}
+	}
+	return nil
+}
+
+// LinearRetentionByDifficulty interpolates retention linearly from easy at
+// difficulty 1 to hard at difficulty 10.
+func LinearRetentionByDifficulty(easy, hard float64) func(difficulty float64) float64 {
+	return func(difficulty float64) float64 {
+		t := (clampDifficulty(difficulty) - minDifficulty) / (maxDifficulty - minDifficulty)
+		return easy + (hard-easy)*t
 	}
 }
 
@@ -279,12 +588,19 @@ func initialDifficulty(w []float64, r Rating) float64 {
 	return clampDifficulty(rawInitialDifficulty(w, r))
 }
 
-func nextInterval(factor, retention, decay float64, maxInterval int, stability float64) time.Duration {
-	intervalDays := stability / factor * (math.Pow(retention, 1.0/decay) - 1.0)
-	days := math.Min(float64(maxInterval), math.Max(1, math.Round(intervalDays)))
+func nextInterval(factor, retention, decay float64, maxInterval int, scale, stability float64) time.Duration {
+	days := math.Round(exactIntervalDays(factor, retention, decay, maxInterval, scale, stability))
 	return time.Duration(days) * dayDuration
 }
 
+func exactIntervalDays(factor, retention, decay float64, maxInterval int, scale, stability float64) float64 {
+	return math.Min(float64(maxInterval), math.Max(1, impliedIntervalDays(factor, retention, decay, scale, stability)))
+}
+
+func impliedIntervalDays(factor, retention, decay, scale, stability float64) float64 {
+	return scale * stability / factor * (math.Pow(retention, 1.0/decay) - 1.0)
+}
+
 func shortTermStability(w []float64, stability float64, rating Rating) float64 {
 	increase := math.Exp(w[17]*(float64(rating)-3.0+w[18])) * math.Pow(stability, -w[19])
 	finalIncrease := increase
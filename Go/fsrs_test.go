@@ -4,6 +4,7 @@ import (
 	"math"
 	"math/rand"
 	"reflect"
+	"sync"
 	"testing"
 	"time"
 )
@@ -225,6 +226,437 @@ func TestStabilityLowerBound(t *testing.T) {
 	}
 }
 
+func TestDayScaleRelearningStepsUseLongTermStability(t *testing.T) {
+	config := DefaultSchedulerConfig()
+	config.RelearningSteps = []time.Duration{dayDuration, 3 * dayDuration}
+	config.RoundDayScaleSteps = true
+	config.EnableFuzzing = false
+	scheduler, _ := NewScheduler(config, testRand)
+
+	card := Card{CardID: 1, Interval: 10 * dayDuration, Stability: 10, Difficulty: 5, State: Review}
+	card = scheduler.ReviewCard(card, Again, card.Interval)
+	if card.State != Relearning || card.Interval != dayDuration {
+		t.Fatalf("Expected relearning card with a 1 day step, but got %v with interval %v", card.State, card.Interval)
+	}
+
+	early := scheduler.ReviewCard(card, Good, dayDuration-10*time.Minute)
+	late := scheduler.ReviewCard(card, Good, dayDuration+10*time.Minute)
+	shortTerm := shortTermStability(scheduler.w, card.Stability, Good)
+
+	if math.Abs(early.Stability-late.Stability)/late.Stability > 0.01 {
+		t.Errorf("Expected similar stability for reviews at 23:50 and 24:10, but got %v and %v", early.Stability, late.Stability)
+	}
+	if math.Abs(early.Stability-shortTerm) < 1e-9 {
+		t.Errorf("Expected long-term stability update for a slightly early review, but got short-term %v", early.Stability)
+	}
+	if early.Step != 1 || early.Interval != 3*dayDuration {
+		t.Errorf("Expected step 1 with interval 3 days, but got step %v interval %v", early.Step, early.Interval)
+	}
+
+	sameDay := scheduler.ReviewCard(card, Good, 6*time.Hour)
+	if math.Abs(sameDay.Stability-shortTerm) > 1e-9 {
+		t.Errorf("Expected short-term stability %v for a same-day review, but got %v", shortTerm, sameDay.Stability)
+	}
+}
+
+func TestShortTermCutoffByState(t *testing.T) {
+	relearning := Card{CardID: 1, Interval: dayDuration, Stability: 2, Difficulty: 5, State: Relearning}
+	review := Card{CardID: 2, Interval: dayDuration, Stability: 2, Difficulty: 5, State: Review}
+	cases := []struct {
+		round     bool
+		card      Card
+		elapsed   time.Duration
+		shortTerm bool
+	}{
+		{false, relearning, 12 * time.Hour, true},
+		{false, relearning, 23*time.Hour + 50*time.Minute, true},
+		{false, relearning, dayDuration, false},
+		{true, relearning, 11*time.Hour + 59*time.Minute, true},
+		{true, relearning, 12 * time.Hour, false},
+		{true, relearning, 23*time.Hour + 50*time.Minute, false},
+		{true, review, 12 * time.Hour, true},
+		{true, review, 23*time.Hour + 50*time.Minute, true},
+		{true, review, dayDuration, false},
+	}
+
+	for _, c := range cases {
+		config := DefaultSchedulerConfig()
+		config.RoundDayScaleSteps = c.round
+		scheduler, _ := NewScheduler(config, testRand)
+		if actual := scheduler.isShortTermReview(c.card, c.elapsed); actual != c.shortTerm {
+			t.Errorf("Expected short-term %v for a %v card after %v with rounding %v, but got %v", c.shortTerm, c.card.State, c.elapsed, c.round, actual)
+		}
+	}
+}
+
+func TestMinuteScaleRelearningStepsUseShortTermStability(t *testing.T) {
+	config := DefaultSchedulerConfig()
+	config.EnableFuzzing = false
+	scheduler, _ := NewScheduler(config, testRand)
+
+	card := Card{CardID: 1, Interval: 10 * dayDuration, Stability: 10, Difficulty: 5, State: Review}
+	card = scheduler.ReviewCard(card, Again, card.Interval)
+	reviewed := scheduler.ReviewCard(card, Good, 23*time.Hour+50*time.Minute)
+
+	shortTerm := shortTermStability(scheduler.w, card.Stability, Good)
+	if math.Abs(reviewed.Stability-shortTerm) > 1e-9 {
+		t.Errorf("Expected short-term stability %v, but got %v", shortTerm, reviewed.Stability)
+	}
+}
+
+// Expected values come from the Python port for an on-time Again followed by
+// Good 10 minutes later, which graduates from the post-short-term stability.
+func TestLapseThenRelearnSameDay(t *testing.T) {
+	config := DefaultSchedulerConfig()
+	config.EnableFuzzing = false
+	scheduler, _ := NewScheduler(config, testRand)
+
+	cases := []struct {
+		stability, difficulty float64
+		intervalDays          int
+		lapsedStability       float64
+		lapsedDifficulty      float64
+		stability2            float64
+		difficulty2           float64
+		expectedIntervalDays  int
+	}{
+		{20, 5, 20, 1.943581, 8.341762, 1.954789, 8.328649, 2},
+		{3, 8, 3, 0.677034, 9.327842, 0.729866, 9.313742, 1},
+		{100, 2, 100, 3.964154, 7.355683, 3.964154, 7.343555, 4},
+	}
+
+	for _, c := range cases {
+		card := Card{CardID: 1, Interval: time.Duration(c.intervalDays) * dayDuration, Stability: c.stability, Difficulty: c.difficulty, State: Review}
+
+		card = scheduler.ReviewCard(card, Again, card.Interval)
+		if card.State != Relearning || card.Interval != 10*time.Minute {
+			t.Errorf("Expected Relearning with interval 10m, but got %v with %v", card.State, card.Interval)
+		}
+		checkStabilityAndDifficulty(t, c.lapsedStability, c.lapsedDifficulty, card)
+
+		card = scheduler.ReviewCard(card, Good, 10*time.Minute)
+		if card.State != Review {
+			t.Errorf("Expected state Review, but got %v", card.State)
+		}
+		checkStabilityAndDifficulty(t, c.stability2, c.difficulty2, card)
+		if expected := time.Duration(c.expectedIntervalDays) * dayDuration; card.Interval != expected {
+			t.Errorf("Expected interval %v, but got %v", expected, card.Interval)
+		}
+		if expected := scheduler.CalculateNextReviewInterval(card.Stability); card.Interval != expected {
+			t.Errorf("Expected interval %v from the graduating stability, but got %v", expected, card.Interval)
+		}
+	}
+}
+
+func TestLapseThenRelearnSameDayTwoSteps(t *testing.T) {
+	config := DefaultSchedulerConfig()
+	config.EnableFuzzing = false
+	config.RelearningSteps = []time.Duration{10 * time.Minute, time.Hour}
+	scheduler, _ := NewScheduler(config, testRand)
+
+	card := Card{CardID: 1, Interval: 20 * dayDuration, Stability: 20, Difficulty: 5, State: Review}
+	card = scheduler.ReviewCard(card, Again, card.Interval)
+	card = scheduler.ReviewCard(card, Good, 10*time.Minute)
+	if card.Step != 1 || card.Interval != time.Hour {
+		t.Errorf("Expected step 1 with interval 1h, but got step %v with %v", card.Step, card.Interval)
+	}
+	checkStabilityAndDifficulty(t, 1.954789, 8.328649, card)
+
+	card = scheduler.ReviewCard(card, Good, time.Hour)
+	if card.State != Review || card.Interval != 2*dayDuration {
+		t.Errorf("Expected Review with interval 2 days, but got %v with %v", card.State, card.Interval)
+	}
+	checkStabilityAndDifficulty(t, 1.965317, 8.315549, card)
+}
+
+func TestCanonicalOrders(t *testing.T) {
+	expectedRatings := []Rating{Again, Hard, Good, Easy}
+	if !reflect.DeepEqual(expectedRatings, Ratings()) {
+		t.Errorf("Expected ratings %v, but got %v", expectedRatings, Ratings())
+	}
+
+	expectedStates := []State{New, Learning, Review, Relearning}
+	if !reflect.DeepEqual(expectedStates, States()) {
+		t.Errorf("Expected states %v, but got %v", expectedStates, States())
+	}
+
+	Ratings()[0] = Easy
+	if Ratings()[0] != Again {
+		t.Errorf("Expected Ratings to return a fresh slice")
+	}
+}
+
+func TestSortCardsByID(t *testing.T) {
+	cards := []Card{
+		{CardID: 3},
+		{CardID: 1, Step: 1},
+		{CardID: 2},
+		{CardID: 1, Step: 2},
+	}
+
+	sorted := SortCardsByID(cards)
+	expected := []Card{
+		{CardID: 1, Step: 1},
+		{CardID: 1, Step: 2},
+		{CardID: 2},
+		{CardID: 3},
+	}
+	if !reflect.DeepEqual(expected, sorted) {
+		t.Errorf("Expected %v, but got %v", expected, sorted)
+	}
+	if cards[0].CardID != 3 {
+		t.Errorf("Expected input to be left unchanged, but got %v", cards)
+	}
+}
+
+func TestRetentionByDifficulty(t *testing.T) {
+	config := DefaultSchedulerConfig()
+	config.EnableFuzzing = false
+	config.RetentionByDifficulty = LinearRetentionByDifficulty(0.85, 0.93)
+	scheduler, err := NewScheduler(config, testRand)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	easy := Card{CardID: 1, Interval: 10 * dayDuration, Stability: 10, Difficulty: 2, State: Review}
+	hard := Card{CardID: 2, Interval: 10 * dayDuration, Stability: 10, Difficulty: 9, State: Review}
+	easy = scheduler.toReviewState(easy)
+	hard = scheduler.toReviewState(hard)
+
+	if hard.Interval >= easy.Interval {
+		t.Errorf("Expected hard card interval %v to be shorter than easy card interval %v", hard.Interval, easy.Interval)
+	}
+
+	flat := createDefaultScheduler()
+	reviewed := scheduler.ReviewCard(hard, Good, hard.Interval)
+	reference := flat.calculateInitialReviewedCard(hard, Good, hard.Interval)
+	if reviewed.Stability != reference.Stability || reviewed.Difficulty != reference.Difficulty {
+		t.Errorf("Expected memory state to be unaffected, but got %v/%v vs %v/%v", reviewed.Stability, reviewed.Difficulty, reference.Stability, reference.Difficulty)
+	}
+}
+
+func TestRetentionByDifficultyValidation(t *testing.T) {
+	config := DefaultSchedulerConfig()
+	config.RetentionByDifficulty = LinearRetentionByDifficulty(0.85, 1.0)
+	if _, err := NewScheduler(config, testRand); err == nil {
+		t.Errorf("Expected error for retention reaching 1")
+	}
+
+	config.RetentionByDifficulty = func(float64) float64 { return math.NaN() }
+	if _, err := NewScheduler(config, testRand); err == nil {
+		t.Errorf("Expected error for NaN retention")
+	}
+}
+
+func TestRepeatedHardOnFinalStep(t *testing.T) {
+	config := DefaultSchedulerConfig()
+	config.EnableFuzzing = false
+	scheduler, _ := NewScheduler(config, testRand)
+
+	card := scheduler.ReviewCard(NewCard(1), Good, 0)
+	if card.Step != len(config.LearningSteps)-1 {
+		t.Fatalf("Expected card on the final step, but got step %d", card.Step)
+	}
+
+	for i := range 20 {
+		card = scheduler.ReviewCard(card, Hard, card.Interval)
+		if card.State != Learning || card.Step != 1 || card.Interval != config.LearningSteps[1] {
+			t.Fatalf("Expected card to repeat the final step after %d Hard ratings, but got %v step %d interval %v", i+1, card.State, card.Step, card.Interval)
+		}
+		if card.ConsecutiveHard != i+1 {
+			t.Errorf("Expected %d consecutive Hard ratings, but got %d", i+1, card.ConsecutiveHard)
+		}
+	}
+
+	card = scheduler.ReviewCard(card, Good, card.Interval)
+	if card.State != Review || card.Interval < dayDuration {
+		t.Errorf("Expected Good to graduate the card, but got %v with interval %v", card.State, card.Interval)
+	}
+	if card.ConsecutiveHard != 0 {
+		t.Errorf("Expected Hard counter to reset, but got %d", card.ConsecutiveHard)
+	}
+}
+
+func TestHardGraduationLimit(t *testing.T) {
+	config := DefaultSchedulerConfig()
+	config.EnableFuzzing = false
+	config.HardGraduationLimit = 3
+	scheduler, _ := NewScheduler(config, testRand)
+
+	card := NewCard(1)
+	card = scheduler.ReviewCard(card, Hard, 0)
+	if card.State != Learning || card.ConsecutiveHard != 1 {
+		t.Fatalf("Expected Hard on the first step not to graduate, but got %v", card.State)
+	}
+
+	card = scheduler.ReviewCard(card, Good, card.Interval)
+	for i := 1; i < 3; i++ {
+		card = scheduler.ReviewCard(card, Hard, card.Interval)
+		if card.State != Learning {
+			t.Fatalf("Expected card to stay in learning after %d Hard ratings, but got %v", i, card.State)
+		}
+	}
+
+	card = scheduler.ReviewCard(card, Hard, card.Interval)
+	if card.State != Review || card.Interval < dayDuration {
+		t.Errorf("Expected graduation after 3 Hard ratings, but got %v with interval %v", card.State, card.Interval)
+	}
+
+	config.HardGraduationLimit = -1
+	if _, err := NewScheduler(config, testRand); err == nil {
+		t.Errorf("Expected an error for a negative limit")
+	}
+}
+
+func TestAgainStepBehavior(t *testing.T) {
+	steps := []time.Duration{time.Minute, 5 * time.Minute, 10 * time.Minute, 30 * time.Minute}
+	cases := []struct {
+		behavior         AgainStepBehavior
+		expectedStep     int
+		expectedInterval time.Duration
+	}{
+		{ResetToFirst, 0, time.Minute},
+		{StepBack, 1, 5 * time.Minute},
+	}
+
+	for _, c := range cases {
+		config := DefaultSchedulerConfig()
+		config.EnableFuzzing = false
+		config.LearningSteps = steps
+		config.RelearningSteps = steps
+		config.AgainStepBehavior = c.behavior
+		scheduler, _ := NewScheduler(config, testRand)
+
+		card := NewCard(1)
+		for _, rating := range []Rating{Good, Good, Again} {
+			card = scheduler.ReviewCard(card, rating, card.Interval)
+		}
+		if card.Step != c.expectedStep || card.Interval != c.expectedInterval {
+			t.Errorf("Expected learning step %d with interval %v, but got %d with %v", c.expectedStep, c.expectedInterval, card.Step, card.Interval)
+		}
+
+		lapsed := Card{CardID: 2, State: Review, Stability: 20, Difficulty: 5, Interval: 20 * dayDuration}
+		lapsed = scheduler.ReviewCard(lapsed, Again, lapsed.Interval)
+		for _, rating := range []Rating{Good, Good, Again} {
+			lapsed = scheduler.ReviewCard(lapsed, rating, lapsed.Interval)
+		}
+		if lapsed.Step != c.expectedStep || lapsed.Interval != c.expectedInterval {
+			t.Errorf("Expected relearning step %d with interval %v, but got %d with %v", c.expectedStep, c.expectedInterval, lapsed.Step, lapsed.Interval)
+		}
+	}
+
+	config := DefaultSchedulerConfig()
+	config.AgainStepBehavior = 2
+	if _, err := NewScheduler(config, testRand); err == nil {
+		t.Errorf("Expected an error for an unknown again step behavior")
+	}
+}
+
+func TestMaxSameDayUpdates(t *testing.T) {
+	config := DefaultSchedulerConfig()
+	config.EnableFuzzing = false
+	config.LearningSteps = []time.Duration{time.Minute, 5 * time.Minute, 10 * time.Minute, 30 * time.Minute}
+	config.MaxSameDayUpdates = 2
+	scheduler, _ := NewScheduler(config, testRand)
+
+	card := scheduler.ReviewCard(NewCard(1), Good, 0)
+	card = scheduler.ReviewCard(card, Good, card.Interval)
+	card = scheduler.ReviewCard(card, Again, card.Interval)
+	if card.SameDayUpdates != 2 {
+		t.Fatalf("Expected 2 same-day updates, but got %d", card.SameDayUpdates)
+	}
+
+	frozen := scheduler.ReviewCard(card, Good, card.Interval)
+	if frozen.Stability != card.Stability || frozen.Difficulty != card.Difficulty {
+		t.Errorf("Expected stability and difficulty to freeze after the cap, but got %v and %v", frozen.Stability, frozen.Difficulty)
+	}
+	if frozen.Step != card.Step+1 || frozen.Interval != 5*time.Minute || frozen.SameDayUpdates != 2 {
+		t.Errorf("Expected the card to move to the next step, but got %+v", frozen)
+	}
+
+	nextDay := scheduler.ReviewCard(frozen, Good, dayDuration)
+	if nextDay.SameDayUpdates != 0 || nextDay.Stability == frozen.Stability {
+		t.Errorf("Expected a review a day later to reset the counter and update stability, but got %+v", nextDay)
+	}
+	again := scheduler.ReviewCard(nextDay, Good, nextDay.Interval)
+	if again.SameDayUpdates != 1 {
+		t.Errorf("Expected the counter to restart, but got %d", again.SameDayUpdates)
+	}
+
+	config.MaxSameDayUpdates = 0
+	unlimited, _ := NewScheduler(config, testRand)
+	if got := unlimited.ReviewCard(card, Good, card.Interval); got.Stability == card.Stability {
+		t.Errorf("Expected no cap when MaxSameDayUpdates is 0")
+	}
+
+	config.MaxSameDayUpdates = -1
+	if _, err := NewScheduler(config, testRand); err == nil {
+		t.Errorf("Expected an error for a negative cap")
+	}
+}
+
+type panickingSource struct{}
+
+func (panickingSource) Int63() int64 { panic("random source used") }
+func (panickingSource) Seed(int64)   { panic("random source used") }
+
+func TestNewDeterministicScheduler(t *testing.T) {
+	if _, err := NewDeterministicScheduler(DefaultSchedulerConfig()); err == nil {
+		t.Errorf("Expected error when fuzzing is enabled")
+	}
+
+	config := DefaultSchedulerConfig()
+	config.EnableFuzzing = false
+	deterministic, err := NewDeterministicScheduler(config)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	spy, _ := NewScheduler(config, rand.New(panickingSource{}))
+
+	ratings := []Rating{Again, Good, Good, Hard, Easy, Good, Again, Good, Good, Good}
+	for _, scheduler := range []*Scheduler{deterministic, spy} {
+		card := NewCard(1)
+		for i := range 200 {
+			card = scheduler.ReviewCard(card, ratings[i%len(ratings)], card.Interval)
+		}
+	}
+}
+
+func TestIntervalScale(t *testing.T) {
+	config := DefaultSchedulerConfig()
+	config.EnableFuzzing = false
+	scheduler, _ := NewScheduler(config, testRand)
+	config.IntervalScale = 0.5
+	halved, _ := NewScheduler(config, testRand)
+
+	for _, stability := range []float64{10, 30, 100, 400} {
+		full := scheduler.CalculateNextReviewInterval(stability)
+		half := halved.CalculateNextReviewInterval(stability)
+		if math.Abs(float64(half)-float64(full)/2) > float64(dayDuration) {
+			t.Errorf("Expected about %v for stability %v, but got %v", full/2, stability, half)
+		}
+	}
+
+	card := NewCard(1)
+	scaledCard := NewCard(1)
+	card = scheduler.ReviewCard(card, Good, 0)
+	scaledCard = halved.ReviewCard(scaledCard, Good, 0)
+	if card.Interval != scaledCard.Interval || scaledCard.Interval != 10*time.Minute {
+		t.Errorf("Expected learning steps to be unaffected, but got %v and %v", card.Interval, scaledCard.Interval)
+	}
+
+	config.IntervalScale = -1
+	if _, err := NewScheduler(config, testRand); err == nil {
+		t.Errorf("Expected error for a negative interval scale")
+	}
+
+	config.IntervalScale = 0
+	unset, _ := NewScheduler(config, testRand)
+	if unset.CalculateNextReviewInterval(30) != scheduler.CalculateNextReviewInterval(30) {
+		t.Errorf("Expected an unset interval scale to behave as 1")
+	}
+}
+
 func runReviews(scheduler *Scheduler, reviews []struct {
 	rating   Rating
 	interval int
@@ -245,3 +677,76 @@ func checkStabilityAndDifficulty(t *testing.T, expectedStability, expectedDiffic
 		t.Errorf("Expected difficulty %v, but got %v", expectedDifficulty, card.Difficulty)
 	}
 }
+
+func TestNewSchedulerPerRequestConcurrently(t *testing.T) {
+	shared := make([]float64, 19, 21)
+	copy(shared, DefaultSchedulerConfig().Parameters)
+	config := DefaultSchedulerConfig()
+	config.Parameters = shared
+	config.EnableFuzzing = false
+
+	reference, _ := NewDeterministicScheduler(config)
+	card := Card{CardID: 1, Interval: 10 * dayDuration, Stability: 10, Difficulty: 5, State: Review}
+	expected := reference.ReviewCard(card, Good, 12*dayDuration)
+
+	var wg sync.WaitGroup
+	results := make([]Card, 64)
+	for i := range results {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 100 {
+				scheduler, err := NewDeterministicScheduler(config)
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				results[i] = scheduler.ReviewCard(card, Good, 12*dayDuration)
+			}
+		}()
+	}
+	wg.Wait()
+
+	for _, result := range results {
+		if result != expected {
+			t.Errorf("Expected %+v, but got %+v", expected, result)
+		}
+	}
+	if shared[:cap(shared)][19] != 0 {
+		t.Errorf("Expected spare capacity of the parameters to stay untouched, but got %v", shared[:cap(shared)][19:])
+	}
+}
+
+func BenchmarkNewSchedulerPerReview(b *testing.B) {
+	config := DefaultSchedulerConfig()
+	card := Card{CardID: 1, Interval: 10 * dayDuration, Stability: 10, Difficulty: 5, State: Review}
+	random := rand.New(rand.NewSource(1))
+	for b.Loop() {
+		scheduler, _ := NewScheduler(config, random)
+		scheduler.ReviewCard(card, Good, 12*dayDuration)
+	}
+}
+
+func TestEmptyParametersUseDefaults(t *testing.T) {
+	expected := createDefaultScheduler().ReviewCard(NewCard(1), Good, 0)
+	for _, parameters := range [][]float64{nil, {}} {
+		config := DefaultSchedulerConfig()
+		config.Parameters = parameters
+		scheduler, err := NewScheduler(config, testRand)
+		if err != nil {
+			t.Fatalf("Unexpected error for %v: %v", parameters, err)
+		}
+		if !scheduler.UsingDefaultParameters() {
+			t.Errorf("Expected %v parameters to be marked as defaults", parameters)
+		}
+		if card := scheduler.ReviewCard(NewCard(1), Good, 0); card != expected {
+			t.Errorf("Expected %+v, but got %+v", expected, card)
+		}
+	}
+
+	config := DefaultSchedulerConfig()
+	config.Parameters = []float64{0.5}
+	if _, err := NewScheduler(config, testRand); err == nil {
+		t.Errorf("Expected an error for a single parameter")
+	}
+}
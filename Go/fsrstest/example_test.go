@@ -0,0 +1,53 @@
+package fsrstest_test
+
+import (
+	"fmt"
+	"time"
+
+	fsrs "fsrs-go"
+	"fsrs-go/fsrstest"
+)
+
+// queue is the kind of application code Scripted stands in under: it keeps
+// the cards still due today and stores every review.
+type queue struct {
+	algorithm fsrs.Algorithm
+	due       []fsrs.Card
+	stored    []fsrs.ReviewLog
+}
+
+func (q *queue) answer(rating fsrs.Rating) error {
+	card := q.due[0]
+	result, err := q.algorithm.Review(card, rating, card.Interval)
+	if err != nil {
+		return err
+	}
+	q.stored = append(q.stored, result.Log)
+	q.due = q.due[1:]
+	if result.Card.Interval < 24*time.Hour {
+		q.due = append(q.due, result.Card)
+	}
+	return nil
+}
+
+func ExampleScripted() {
+	scripted := fsrstest.NewScripted().
+		On(1, fsrs.Again, fsrs.Card{CardID: 1, State: fsrs.Learning, Interval: time.Minute}).
+		On(1, fsrs.Good, fsrs.Card{CardID: 1, State: fsrs.Review, Interval: 2 * 24 * time.Hour})
+
+	q := &queue{algorithm: scripted, due: []fsrs.Card{fsrs.NewCard(1)}}
+	q.answer(fsrs.Again)
+	fmt.Println("due after Again:", len(q.due))
+	q.answer(fsrs.Good)
+	fmt.Println("due after Good:", len(q.due))
+	fmt.Println("stored logs:", len(q.stored), "calls:", len(scripted.Calls()))
+
+	scripted.FailWith(fmt.Errorf("scheduler down"))
+	q.due = []fsrs.Card{fsrs.NewCard(1)}
+	fmt.Println(q.answer(fsrs.Good), len(q.due))
+	// Output:
+	// due after Again: 1
+	// due after Good: 0
+	// stored logs: 2 calls: 2
+	// scheduler down 1
+}
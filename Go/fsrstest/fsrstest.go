@@ -0,0 +1,93 @@
+// Package fsrstest provides test doubles for code built on fsrs.Algorithm.
+package fsrstest
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	fsrs "fsrs-go"
+)
+
+var ErrUnscripted = errors.New("no scripted result")
+
+// Call is one Review received by a Scripted.
+type Call struct {
+	Card    fsrs.Card
+	Rating  fsrs.Rating
+	Elapsed time.Duration
+}
+
+type scriptKey struct {
+	cardID int64
+	rating fsrs.Rating
+}
+
+// Scripted is an fsrs.Algorithm that returns pre-programmed cards per
+// (card ID, rating) instead of running FSRS. It records every call and is
+// safe for concurrent use. Review options are accepted and ignored.
+type Scripted struct {
+	mu      sync.Mutex
+	results map[scriptKey]fsrs.Card
+	err     error
+	calls   []Call
+}
+
+func NewScripted() *Scripted {
+	return &Scripted{results: make(map[scriptKey]fsrs.Card)}
+}
+
+// On makes Review return result when the card with cardID is rated rating.
+func (s *Scripted) On(cardID int64, rating fsrs.Rating, result fsrs.Card) *Scripted {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.results[scriptKey{cardID, rating}] = result
+	return s
+}
+
+// FailWith makes every later Review return err, or restores scripted
+// results when err is nil. Failing calls are still recorded.
+func (s *Scripted) FailWith(err error) *Scripted {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.err = err
+	return s
+}
+
+// Review returns the scripted card with a matching log. It fails with the
+// FailWith error when one is set and with ErrUnscripted when nothing was
+// scripted for the card and rating.
+func (s *Scripted) Review(card fsrs.Card, rating fsrs.Rating, elapsed time.Duration, opts ...fsrs.ReviewOption) (fsrs.ReviewResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.calls = append(s.calls, Call{Card: card, Rating: rating, Elapsed: elapsed})
+
+	if s.err != nil {
+		return fsrs.ReviewResult{}, s.err
+	}
+	result, ok := s.results[scriptKey{card.CardID, rating}]
+	if !ok {
+		return fsrs.ReviewResult{}, fmt.Errorf("%w: card %d rated %d", ErrUnscripted, card.CardID, rating)
+	}
+	return fsrs.ReviewResult{
+		Card: result,
+		Log: fsrs.ReviewLog{
+			CardID:            card.CardID,
+			Rating:            rating,
+			State:             card.State,
+			ScheduledInterval: card.Interval,
+			Elapsed:           elapsed,
+			Stability:         result.Stability,
+			Difficulty:        result.Difficulty,
+		},
+		Transition: fsrs.Transition{From: card.State, To: result.State},
+	}, nil
+}
+
+// Calls returns the calls received so far, in order.
+func (s *Scripted) Calls() []Call {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Call(nil), s.calls...)
+}
@@ -0,0 +1,75 @@
+package fsrstest
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	fsrs "fsrs-go"
+)
+
+var _ fsrs.Algorithm = (*Scripted)(nil)
+
+func TestScriptedReturnsScriptedCards(t *testing.T) {
+	graduated := fsrs.Card{CardID: 1, State: fsrs.Review, Interval: 3 * 24 * time.Hour, Stability: 3, Difficulty: 5}
+	scripted := NewScripted().On(1, fsrs.Good, graduated)
+
+	card := fsrs.Card{CardID: 1, State: fsrs.Learning, Step: 1, Interval: 10 * time.Minute}
+	result, err := scripted.Review(card, fsrs.Good, 10*time.Minute)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result.Card != graduated {
+		t.Errorf("Expected %+v, but got %+v", graduated, result.Card)
+	}
+	if result.Log.CardID != 1 || result.Log.Rating != fsrs.Good || result.Log.State != fsrs.Learning || result.Log.Stability != 3 {
+		t.Errorf("Expected a log of the scripted review, but got %+v", result.Log)
+	}
+	if result.Transition != (fsrs.Transition{From: fsrs.Learning, To: fsrs.Review}) {
+		t.Errorf("Expected Learning to Review, but got %+v", result.Transition)
+	}
+
+	if _, err := scripted.Review(card, fsrs.Again, 10*time.Minute); !errors.Is(err, ErrUnscripted) {
+		t.Errorf("Expected ErrUnscripted, but got %v", err)
+	}
+}
+
+func TestScriptedRecordsCallsAndFails(t *testing.T) {
+	scripted := NewScripted().On(1, fsrs.Good, fsrs.Card{CardID: 1})
+	errStore := errors.New("store unavailable")
+
+	scripted.Review(fsrs.NewCard(1), fsrs.Good, 0)
+	scripted.FailWith(errStore)
+	if _, err := scripted.Review(fsrs.NewCard(1), fsrs.Good, time.Hour); !errors.Is(err, errStore) {
+		t.Errorf("Expected %v, but got %v", errStore, err)
+	}
+	scripted.FailWith(nil)
+	if _, err := scripted.Review(fsrs.NewCard(1), fsrs.Good, 0); err != nil {
+		t.Errorf("Expected no error after clearing the failure, but got %v", err)
+	}
+
+	calls := scripted.Calls()
+	if len(calls) != 3 || calls[1].Elapsed != time.Hour || calls[1].Rating != fsrs.Good {
+		t.Errorf("Expected three recorded calls, but got %+v", calls)
+	}
+}
+
+func TestScriptedConcurrentUse(t *testing.T) {
+	scripted := NewScripted().On(1, fsrs.Good, fsrs.Card{CardID: 1})
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 100 {
+				scripted.Review(fsrs.NewCard(1), fsrs.Good, 0)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if len(scripted.Calls()) != 800 {
+		t.Errorf("Expected 800 calls, but got %d", len(scripted.Calls()))
+	}
+}
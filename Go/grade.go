@@ -0,0 +1,61 @@
+package fsrs
+
+// Grade is a coarse memory strength for display.
+type Grade int
+
+const (
+	GradeNew      Grade = 0
+	GradeLearning Grade = 1
+	GradeBronze   Grade = 2
+	GradeSilver   Grade = 3
+	GradeGold     Grade = 4
+	GradePlatinum Grade = 5
+
+	GradeCount = 6
+)
+
+// GradeThresholds are the stabilities, in days, at which a Review card
+// reaches each grade above Bronze.
+type GradeThresholds struct {
+	Silver   float64
+	Gold     float64
+	Platinum float64
+}
+
+// DefaultGradeThresholds grade Review cards by stability: Bronze under a
+// week, Silver under a month, Gold under six months and Platinum beyond.
+func DefaultGradeThresholds() GradeThresholds {
+	return GradeThresholds{
+		Silver:   7,
+		Gold:     30,
+		Platinum: 180,
+	}
+}
+
+// MemoryGrade grades the card against DefaultGradeThresholds.
+func MemoryGrade(card Card) Grade {
+	return MemoryGradeWith(card, DefaultGradeThresholds())
+}
+
+// MemoryGradeWith grades a Review card by its stability against
+// thresholds. New cards are GradeNew and Learning or Relearning cards
+// GradeLearning, whatever their stability.
+func MemoryGradeWith(card Card, thresholds GradeThresholds) Grade {
+	switch card.State {
+	case New:
+		return GradeNew
+	case Learning, Relearning:
+		return GradeLearning
+	}
+
+	switch {
+	case card.Stability >= thresholds.Platinum:
+		return GradePlatinum
+	case card.Stability >= thresholds.Gold:
+		return GradeGold
+	case card.Stability >= thresholds.Silver:
+		return GradeSilver
+	default:
+		return GradeBronze
+	}
+}
@@ -0,0 +1,49 @@
+package fsrs
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMemoryGrade(t *testing.T) {
+	cases := []struct {
+		card     Card
+		expected Grade
+	}{
+		{NewCard(1), GradeNew},
+		{Card{CardID: 2, State: Learning, Stability: 400, Difficulty: 5}, GradeLearning},
+		{Card{CardID: 3, State: Relearning, Stability: 2, Difficulty: 5}, GradeLearning},
+		{Card{CardID: 4, State: Review, Stability: 3, Difficulty: 5}, GradeBronze},
+		{Card{CardID: 5, State: Review, Stability: 7, Difficulty: 5}, GradeSilver},
+		{Card{CardID: 6, State: Review, Stability: 90, Difficulty: 5}, GradeGold},
+		{Card{CardID: 7, State: Review, Stability: 365, Difficulty: 5}, GradePlatinum},
+	}
+	for _, c := range cases {
+		if got := MemoryGrade(c.card); got != c.expected {
+			t.Errorf("Expected grade %v for %+v, but got %v", c.expected, c.card, got)
+		}
+	}
+
+	tuned := DefaultGradeThresholds()
+	tuned.Platinum = 60
+	if got := MemoryGradeWith(cases[5].card, tuned); got != GradePlatinum {
+		t.Errorf("Expected a lower platinum threshold to apply, but got %v", got)
+	}
+	if got := MemoryGradeWith(cases[4].card, tuned); got != GradeSilver {
+		t.Errorf("Expected the other thresholds to be kept, but got %v", got)
+	}
+	if got := MemoryGradeWith(cases[3].card, GradeThresholds{Gold: 30, Platinum: 180}); got != GradeSilver {
+		t.Errorf("Expected a zero silver threshold to be honored, but got %v", got)
+	}
+
+	scheduler := createDefaultScheduler()
+
+	var scheduled []ScheduledCard
+	for _, c := range cases {
+		scheduled = append(scheduled, ScheduledCard{Card: c.card})
+	}
+	report := DeckHealth(scheduler, scheduled, nil, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	if report.Grades != [GradeCount]int{1, 2, 1, 1, 1, 1} {
+		t.Errorf("Expected grade counts in the health report, but got %v", report.Grades)
+	}
+}
@@ -0,0 +1,200 @@
+package fsrs
+
+import "time"
+
+type Severity int
+
+const (
+	SeverityOK   Severity = 0
+	SeverityWarn Severity = 1
+	SeverityBad  Severity = 2
+)
+
+const (
+	leechLapses            = 8
+	saturatedDifficulty    = 9.5
+	retentionWarnGap       = 0.05
+	retentionBadGap        = 0.10
+	backlogWarnFraction    = 0.10
+	backlogBadFraction     = 0.25
+	leechWarnFraction      = 0.02
+	leechBadFraction       = 0.05
+	saturationWarnFraction = 0.20
+	saturationBadFraction  = 0.40
+	stalenessWarnFraction  = 0.25
+	stalenessBadFraction   = 0.50
+)
+
+type HealthMetric struct {
+	Value    float64
+	Severity Severity
+}
+
+// HealthReport summarizes a deck. BacklogAges counts overdue cards by days
+// overdue: under 1, under 7, under 30, and 30 or more. Burden is the
+// expected number of reviews per day of the Review cards. Grades counts
+// cards by MemoryGrade. ParameterStaleness counts the reviews since the
+// scheduler's ParametersFitted, rated by their share of all reviews; it
+// stays ok when the fit time is not recorded.
+type HealthReport struct {
+	DesiredRetention     float64
+	ObservedRetention    HealthMetric
+	Backlog              HealthMetric
+	BacklogAges          [4]int
+	Leeches              HealthMetric
+	DifficultySaturation HealthMetric
+	ParameterStaleness   HealthMetric
+	Burden               float64
+	Grades               [GradeCount]int
+}
+
+func DeckHealth(scheduler *Scheduler, cards []ScheduledCard, logs []ReviewLog, now time.Time) HealthReport {
+	plain := make([]Card, len(cards))
+	for i, scheduled := range cards {
+		plain[i] = scheduled.Card
+	}
+
+	report := HealthReport{DesiredRetention: scheduler.config.DesiredRetention}
+
+	observed, ok := ObservedRetention(logs)
+	report.ObservedRetention.Value = observed
+	if ok {
+		report.ObservedRetention.Severity = severity(scheduler.config.DesiredRetention-observed, retentionWarnGap, retentionBadGap)
+	}
+
+	reviewed := 0
+	saturated := 0
+	for _, card := range plain {
+		report.Grades[MemoryGrade(card)]++
+		if card.State == New {
+			continue
+		}
+		reviewed++
+		if card.Difficulty >= saturatedDifficulty {
+			saturated++
+		}
+	}
+
+	backlog, ages := Backlog(plain, now)
+	report.Backlog.Value = float64(backlog)
+	report.BacklogAges = ages
+	report.Leeches.Value = float64(LeechCount(logs, leechLapses))
+	report.DifficultySaturation.Value = fraction(saturated, reviewed)
+	report.Burden = Burden(plain)
+	if fitted := scheduler.config.ParametersFitted; !fitted.IsZero() {
+		graded := 0
+		for _, log := range logs {
+			if log.Kind == LogReview {
+				graded++
+			}
+		}
+		since := ReviewsSince(logs, fitted)
+		report.ParameterStaleness.Value = float64(since)
+		report.ParameterStaleness.Severity = severity(fraction(since, graded), stalenessWarnFraction, stalenessBadFraction)
+	}
+
+	report.Backlog.Severity = severity(fraction(backlog, reviewed), backlogWarnFraction, backlogBadFraction)
+	report.Leeches.Severity = severity(fraction(int(report.Leeches.Value), reviewed), leechWarnFraction, leechBadFraction)
+	report.DifficultySaturation.Severity = severity(report.DifficultySaturation.Value, saturationWarnFraction, saturationBadFraction)
+	return report
+}
+
+// ObservedRetention returns the pass rate of reviews of cards in the Review
+// state, and false when logs contain no such reviews.
+func ObservedRetention(logs []ReviewLog) (float64, bool) {
+	reviews := 0
+	passed := 0
+	for _, log := range logs {
+		if log.Kind != LogReview || log.State != Review {
+			continue
+		}
+		reviews++
+		if log.Rating != Again {
+			passed++
+		}
+	}
+	if reviews == 0 {
+		return 0, false
+	}
+	return float64(passed) / float64(reviews), true
+}
+
+func Backlog(cards []Card, now time.Time) (int, [4]int) {
+	var ages [4]int
+	count := 0
+	for _, card := range cards {
+		if card.State == New || !card.Due().Before(now) {
+			continue
+		}
+		count++
+		overdueDays := now.Sub(card.Due()).Hours() / dayDuration.Hours()
+		switch {
+		case overdueDays < 1:
+			ages[0]++
+		case overdueDays < 7:
+			ages[1]++
+		case overdueDays < 30:
+			ages[2]++
+		default:
+			ages[3]++
+		}
+	}
+	return count, ages
+}
+
+// LeechCount returns the number of cards that lapsed, rated Again in the
+// Review state, at least threshold times.
+func LeechCount(logs []ReviewLog, threshold int) int {
+	lapses := make(map[int64]int)
+	for _, log := range logs {
+		if log.Kind == LogReview && log.State == Review && log.Rating == Again {
+			lapses[log.CardID]++
+		}
+	}
+	count := 0
+	for _, n := range lapses {
+		if n >= threshold {
+			count++
+		}
+	}
+	return count
+}
+
+// ReviewsSince returns the number of graded reviews in logs after since.
+func ReviewsSince(logs []ReviewLog, since time.Time) int {
+	count := 0
+	for _, log := range logs {
+		if log.Kind == LogReview && log.ReviewTime.After(since) {
+			count++
+		}
+	}
+	return count
+}
+
+func Burden(cards []Card) float64 {
+	burden := 0.0
+	for _, card := range cards {
+		if card.State == Review && card.Interval > 0 {
+			burden += dayDuration.Hours() / card.Interval.Hours()
+		}
+	}
+	return burden
+}
+
+func severity(value, warn, bad float64) Severity {
+	switch {
+	case value > bad:
+		return SeverityBad
+	case value > warn:
+		return SeverityWarn
+	default:
+		return SeverityOK
+	}
+}
+
+func fraction(n, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(n) / float64(total)
+}
@@ -0,0 +1,166 @@
+package fsrs
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func healthCard(id int64, lastReview time.Time, difficulty float64) ScheduledCard {
+	return ScheduledCard{Card: Card{
+		CardID:     id,
+		State:      Review,
+		Stability:  20,
+		Difficulty: difficulty,
+		Interval:   20 * dayDuration,
+		LastReview: lastReview,
+	}}
+}
+
+func TestDeckHealthHealthyDeck(t *testing.T) {
+	scheduler := createDefaultScheduler()
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	var cards []ScheduledCard
+	var logs []ReviewLog
+	for id := int64(1); id <= 100; id++ {
+		cards = append(cards, healthCard(id, now.AddDate(0, 0, -5), 5))
+		rating := Good
+		if id%10 == 0 {
+			rating = Again
+		}
+		logs = append(logs, ReviewLog{CardID: id, Rating: rating, State: Review, ReviewTime: now.AddDate(0, 0, -5)})
+	}
+
+	report := DeckHealth(scheduler, cards, logs, now)
+	for name, metric := range map[string]HealthMetric{
+		"retention":  report.ObservedRetention,
+		"backlog":    report.Backlog,
+		"leeches":    report.Leeches,
+		"saturation": report.DifficultySaturation,
+		"staleness":  report.ParameterStaleness,
+	} {
+		if metric.Severity != SeverityOK {
+			t.Errorf("Expected %s to be ok, but got %+v", name, metric)
+		}
+	}
+	if math.Abs(report.ObservedRetention.Value-0.9) > 1e-9 {
+		t.Errorf("Expected observed retention 0.9, but got %v", report.ObservedRetention.Value)
+	}
+	if math.Abs(report.Burden-5) > 1e-9 {
+		t.Errorf("Expected burden of 5 reviews per day, but got %v", report.Burden)
+	}
+}
+
+func TestDeckHealthLowRetention(t *testing.T) {
+	scheduler := createDefaultScheduler()
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	var cards []ScheduledCard
+	var logs []ReviewLog
+	for id := int64(1); id <= 50; id++ {
+		cards = append(cards, healthCard(id, now.AddDate(0, 0, -2), 5))
+		rating := Good
+		if id <= 15 {
+			rating = Again
+		}
+		logs = append(logs, ReviewLog{CardID: id, Rating: rating, State: Review, ReviewTime: now.AddDate(0, 0, -2)})
+	}
+
+	report := DeckHealth(scheduler, cards, logs, now)
+	if report.ObservedRetention.Severity != SeverityBad || math.Abs(report.ObservedRetention.Value-0.7) > 1e-9 {
+		t.Errorf("Expected retention 0.7 to be bad, but got %+v", report.ObservedRetention)
+	}
+}
+
+func TestDeckHealthBacklog(t *testing.T) {
+	scheduler := createDefaultScheduler()
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	var cards []ScheduledCard
+	for id := int64(1); id <= 34; id++ {
+		cards = append(cards, healthCard(id, now.AddDate(0, 0, -3), 5))
+	}
+	for i, overdue := range []time.Duration{12 * time.Hour, 3 * dayDuration, 3 * dayDuration, 10 * dayDuration, 40 * dayDuration, 40 * dayDuration} {
+		cards = append(cards, healthCard(int64(100+i), now.Add(-20*dayDuration-overdue), 5))
+	}
+
+	report := DeckHealth(scheduler, cards, nil, now)
+	if report.Backlog.Severity != SeverityWarn || report.Backlog.Value != 6 {
+		t.Errorf("Expected a backlog warning for 6 cards, but got %+v", report.Backlog)
+	}
+	if report.BacklogAges != [4]int{1, 2, 1, 2} {
+		t.Errorf("Expected backlog ages [1 2 1 2], but got %v", report.BacklogAges)
+	}
+}
+
+func TestDeckHealthLeeches(t *testing.T) {
+	scheduler := createDefaultScheduler()
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	var cards []ScheduledCard
+	var logs []ReviewLog
+	for id := int64(1); id <= 100; id++ {
+		cards = append(cards, healthCard(id, now.AddDate(0, 0, -1), 5))
+	}
+	for id := int64(1); id <= 3; id++ {
+		for day := range leechLapses {
+			logs = append(logs, ReviewLog{CardID: id, Rating: Again, State: Review, ReviewTime: now.AddDate(0, 0, -30+day)})
+		}
+	}
+
+	report := DeckHealth(scheduler, cards, logs, now)
+	if report.Leeches.Severity != SeverityWarn || report.Leeches.Value != 3 {
+		t.Errorf("Expected a leech warning for 3 cards, but got %+v", report.Leeches)
+	}
+}
+
+func TestDeckHealthDifficultySaturation(t *testing.T) {
+	scheduler := createDefaultScheduler()
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	var cards []ScheduledCard
+	for id := int64(1); id <= 10; id++ {
+		difficulty := 4.0
+		if id <= 5 {
+			difficulty = 9.8
+		}
+		cards = append(cards, healthCard(id, now.AddDate(0, 0, -1), difficulty))
+	}
+	cards = append(cards, ScheduledCard{Card: NewCard(11)})
+
+	report := DeckHealth(scheduler, cards, nil, now)
+	if report.DifficultySaturation.Severity != SeverityBad || report.DifficultySaturation.Value != 0.5 {
+		t.Errorf("Expected saturated difficulty to be bad, but got %+v", report.DifficultySaturation)
+	}
+}
+
+func TestDeckHealthParameterStaleness(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	fitted := now.AddDate(0, 0, -20)
+	var cards []ScheduledCard
+	var logs []ReviewLog
+	for id := int64(1); id <= 20; id++ {
+		cards = append(cards, healthCard(id, now.AddDate(0, 0, -10), 5))
+		logs = append(logs, ReviewLog{CardID: id, Rating: Good, State: Review, ReviewTime: now.AddDate(0, 0, -40)})
+		if id <= 10 {
+			logs = append(logs, ReviewLog{CardID: id, Rating: Good, State: Review, ReviewTime: now.AddDate(0, 0, -10)})
+		}
+	}
+	logs = append(logs, ReviewLog{CardID: 1, Kind: LogDefer, ReviewTime: now.AddDate(0, 0, -5)})
+
+	scheduler := createDefaultScheduler()
+	if report := DeckHealth(scheduler, cards, logs, now); report.ParameterStaleness != (HealthMetric{}) {
+		t.Errorf("Expected no staleness without a fit time, but got %+v", report.ParameterStaleness)
+	}
+
+	config := DefaultSchedulerConfig()
+	config.ParametersFitted = fitted
+	scheduler, _ = NewScheduler(config, testRand)
+	report := DeckHealth(scheduler, cards, logs, now)
+	if report.ParameterStaleness.Severity != SeverityWarn || report.ParameterStaleness.Value != 10 {
+		t.Errorf("Expected a staleness warning for 10 reviews, but got %+v", report.ParameterStaleness)
+	}
+
+	config.ParametersFitted = now.AddDate(0, 0, -50)
+	scheduler, _ = NewScheduler(config, testRand)
+	report = DeckHealth(scheduler, cards, logs, now)
+	if report.ParameterStaleness.Severity != SeverityBad || report.ParameterStaleness.Value != 30 {
+		t.Errorf("Expected every review since the fit to be bad, but got %+v", report.ParameterStaleness)
+	}
+}
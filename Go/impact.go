@@ -0,0 +1,54 @@
+package fsrs
+
+import "math"
+
+const retentionImpactTolerance = 0.005
+
+// ImpactReport describes how a deck scheduled under the current desired
+// retention lines up with a new target. A card is over-scheduled when its
+// retrievability at the existing due date falls below the new target, so
+// it will be reviewed later than the new setting asks for, and
+// under-scheduled when it stays above it. Cards within 0.005 of the
+// target are on target. IntervalRatio is how much longer future intervals
+// will be than current ones.
+type ImpactReport struct {
+	CurrentRetention      float64
+	NewRetention          float64
+	OverScheduled         int
+	UnderScheduled        int
+	OnTarget              int
+	MeanDueRetrievability float64
+	IntervalRatio         float64
+}
+
+func RetentionChangeImpact(cards []ScheduledCard, scheduler *Scheduler, newRetention float64) ImpactReport {
+	report := ImpactReport{
+		CurrentRetention: scheduler.config.DesiredRetention,
+		NewRetention:     newRetention,
+		IntervalRatio:    scheduler.IntervalRatio(newRetention, scheduler.config.DesiredRetention),
+	}
+
+	reviewed := 0
+	total := 0.0
+	for _, scheduled := range cards {
+		card := scheduled.Card
+		if card.State == New || card.Stability <= 0 {
+			continue
+		}
+		r := scheduler.retrievability(card.Stability, card.Interval)
+		switch {
+		case math.Abs(r-newRetention) <= retentionImpactTolerance:
+			report.OnTarget++
+		case r < newRetention:
+			report.OverScheduled++
+		default:
+			report.UnderScheduled++
+		}
+		total += r
+		reviewed++
+	}
+	if reviewed > 0 {
+		report.MeanDueRetrievability = total / float64(reviewed)
+	}
+	return report
+}
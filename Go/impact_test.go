@@ -0,0 +1,51 @@
+package fsrs
+
+import (
+	"math"
+	"testing"
+)
+
+func TestRetentionChangeImpact(t *testing.T) {
+	config := DefaultSchedulerConfig()
+	config.EnableFuzzing = false
+	scheduler, _ := NewScheduler(config, testRand)
+
+	var cards []ScheduledCard
+	for i, stability := range []float64{3, 12, 45, 200} {
+		interval := scheduler.CalculateNextReviewInterval(stability)
+		card := Card{CardID: int64(i + 1), State: Review, Stability: stability, Difficulty: 5, Interval: interval}
+		cards = append(cards, ScheduledCard{Card: card})
+	}
+	cards = append(cards, ScheduledCard{Card: NewCard(5)})
+
+	same := RetentionChangeImpact(cards, scheduler, 0.9)
+	if same.OnTarget != 4 || same.OverScheduled != 0 || same.UnderScheduled != 0 {
+		t.Errorf("Expected every card on target, but got %+v", same)
+	}
+	if math.Abs(same.IntervalRatio-1) > 1e-9 {
+		t.Errorf("Expected interval ratio 1, but got %v", same.IntervalRatio)
+	}
+
+	higher := RetentionChangeImpact(cards, scheduler, 0.95)
+	if higher.OverScheduled != 4 || higher.IntervalRatio >= 1 {
+		t.Errorf("Expected every card over-scheduled with shorter future intervals, but got %+v", higher)
+	}
+
+	lower := RetentionChangeImpact(cards, scheduler, 0.8)
+	if lower.UnderScheduled != 4 || lower.IntervalRatio <= 1 {
+		t.Errorf("Expected every card under-scheduled with longer future intervals, but got %+v", lower)
+	}
+	if math.Abs(lower.MeanDueRetrievability-0.9) > 0.01 {
+		t.Errorf("Expected mean due retrievability near 0.9, but got %v", lower.MeanDueRetrievability)
+	}
+	if lower.CurrentRetention != 0.9 || lower.NewRetention != 0.8 {
+		t.Errorf("Expected retentions 0.9 and 0.8, but got %v and %v", lower.CurrentRetention, lower.NewRetention)
+	}
+
+	// A card already overdue relative to the current schedule is
+	// over-scheduled even for a slightly lower target.
+	late := Card{CardID: 6, State: Review, Stability: 10, Difficulty: 5, Interval: 40 * dayDuration}
+	if report := RetentionChangeImpact([]ScheduledCard{{Card: late}}, scheduler, 0.85); report.OverScheduled != 1 {
+		t.Errorf("Expected the long-interval card to be over-scheduled, but got %+v", report)
+	}
+}
@@ -0,0 +1,49 @@
+package fsrs
+
+// RatingModel gives the probability of each rating, in Ratings order.
+type RatingModel interface {
+	FirstRating() [4]float64
+	Rating(retrievability float64) [4]float64
+}
+
+// DefaultRatingModel rates Again with probability 1 - retrievability and
+// splits the remaining probability by RecallRatings (Hard, Good, Easy).
+type DefaultRatingModel struct {
+	FirstRatings  [4]float64
+	RecallRatings [3]float64
+}
+
+func NewDefaultRatingModel() DefaultRatingModel {
+	return DefaultRatingModel{
+		FirstRatings:  [4]float64{0.24, 0.094, 0.495, 0.171},
+		RecallRatings: [3]float64{0.224, 0.631, 0.145},
+	}
+}
+
+func (m DefaultRatingModel) FirstRating() [4]float64 {
+	return m.FirstRatings
+}
+
+func (m DefaultRatingModel) Rating(retrievability float64) [4]float64 {
+	return [4]float64{
+		1.0 - retrievability,
+		retrievability * m.RecallRatings[0],
+		retrievability * m.RecallRatings[1],
+		retrievability * m.RecallRatings[2],
+	}
+}
+
+func sampleRating(probabilities [4]float64, u float64) Rating {
+	total := 0.0
+	for _, p := range probabilities {
+		total += p
+	}
+	cumulative := 0.0
+	for i, p := range probabilities {
+		cumulative += p / total
+		if u < cumulative {
+			return Ratings()[i]
+		}
+	}
+	return Easy
+}
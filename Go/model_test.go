@@ -0,0 +1,43 @@
+package fsrs
+
+import (
+	"math"
+	"testing"
+)
+
+func TestDefaultRatingModel(t *testing.T) {
+	model := NewDefaultRatingModel()
+	for _, r := range []float64{0, 0.5, 0.9, 1} {
+		probabilities := model.Rating(r)
+		total := 0.0
+		for _, p := range probabilities {
+			total += p
+		}
+		if math.Abs(total-1.0) > 1e-9 {
+			t.Errorf("Expected probabilities to sum to 1 at retrievability %v, but got %v", r, total)
+		}
+		if math.Abs(probabilities[0]-(1-r)) > 1e-12 {
+			t.Errorf("Expected Again probability %v, but got %v", 1-r, probabilities[0])
+		}
+	}
+}
+
+func TestSampleRating(t *testing.T) {
+	probabilities := [4]float64{0.1, 0.2, 0.3, 0.4}
+	cases := []struct {
+		u        float64
+		expected Rating
+	}{
+		{0.05, Again},
+		{0.15, Hard},
+		{0.45, Good},
+		{0.75, Easy},
+		{0.999, Easy},
+	}
+
+	for _, c := range cases {
+		if actual := sampleRating(probabilities, c.u); actual != c.expected {
+			t.Errorf("Expected %v for %v, but got %v", c.expected, c.u, actual)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package fsrs
+
+import (
+	"math"
+	"time"
+)
+
+// MultiScheduler routes each card to the scheduler registered for its
+// ParamsID, falling back to the default scheduler for unknown or empty IDs.
+type MultiScheduler struct {
+	fallback   *Scheduler
+	schedulers map[string]*Scheduler
+}
+
+func NewMultiScheduler(fallback *Scheduler, schedulers map[string]*Scheduler) *MultiScheduler {
+	return &MultiScheduler{fallback: fallback, schedulers: schedulers}
+}
+
+func (m *MultiScheduler) Scheduler(paramsID string) *Scheduler {
+	if s, ok := m.schedulers[paramsID]; ok {
+		return s
+	}
+	return m.fallback
+}
+
+func (m *MultiScheduler) ReviewCard(card Card, rating Rating, reviewInterval time.Duration) Card {
+	return m.Scheduler(card.ParamsID).ReviewCard(card, rating, reviewInterval)
+}
+
+func (m *MultiScheduler) Review(card Card, rating Rating, elapsed time.Duration, opts ...ReviewOption) (ReviewResult, error) {
+	return m.Scheduler(card.ParamsID).Review(card, rating, elapsed, opts...)
+}
+
+// AdoptCards re-derives the stability of Review cards for the weights of
+// to. The retrievability from predicts at the end of the card's current
+// interval is kept, so the card stays due when it was and its next
+// intervals continue smoothly. Other cards, and ParamsID, are left as they
+// are; callers retag adopted cards for the scheduler they now belong to.
+func AdoptCards(cards []Card, from, to *Scheduler) []Card {
+	adopted := make([]Card, len(cards))
+	for i, card := range cards {
+		adopted[i] = card
+		if card.State != Review || card.Stability <= 0 || card.Interval <= 0 {
+			continue
+		}
+		r := from.retrievability(card.Stability, card.Interval)
+		intervalDays := card.Interval.Hours() / dayDuration.Hours()
+		adopted[i].Stability = clampStability(to.factor * intervalDays / (math.Pow(r, 1.0/to.decay) - 1.0))
+	}
+	return adopted
+}
+
+// RescaleStabilities scales the stability of Review cards so that to
+// schedules the same next interval for them as from does, so due dates
+// are preserved when switching parameter sets or desired retention. It
+// differs from AdoptCards, which keeps the retrievability at the current
+// interval instead. Other cards are returned unchanged.
+func RescaleStabilities(cards []Card, from, to *Scheduler) []Card {
+	rescaled := make([]Card, len(cards))
+	for i, card := range cards {
+		rescaled[i] = card
+		if card.State != Review || card.Stability <= 0 {
+			continue
+		}
+		fromDays := impliedIntervalDays(from.factor, from.desiredRetention(card.Difficulty), from.decay, from.intervalScale(), 1)
+		toDays := impliedIntervalDays(to.factor, to.desiredRetention(card.Difficulty), to.decay, to.intervalScale(), 1)
+		rescaled[i].Stability = clampStability(card.Stability * fromDays / toDays)
+	}
+	return rescaled
+}
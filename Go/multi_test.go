@@ -0,0 +1,106 @@
+package fsrs
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestMultiSchedulerRouting(t *testing.T) {
+	config := DefaultSchedulerConfig()
+	config.EnableFuzzing = false
+	user, _ := NewScheduler(config, testRand)
+	config.Parameters = defaultParameters(FSRS5)
+	author, _ := NewScheduler(config, testRand)
+
+	multi := NewMultiScheduler(user, map[string]*Scheduler{"author": author})
+	card := Card{CardID: 1, State: Review, Stability: 10, Difficulty: 5, Interval: 15 * dayDuration}
+
+	tagged := card
+	tagged.ParamsID = "author"
+	if got, expected := multi.ReviewCard(tagged, Good, tagged.Interval), author.ReviewCard(tagged, Good, tagged.Interval); got != expected {
+		t.Errorf("Expected %+v, but got %+v", expected, got)
+	}
+	for _, id := range []string{"", "unknown"} {
+		untagged := card
+		untagged.ParamsID = id
+		if got, expected := multi.ReviewCard(untagged, Good, untagged.Interval), user.ReviewCard(untagged, Good, untagged.Interval); got != expected {
+			t.Errorf("Expected ParamsID %q to use the fallback, but got %+v", id, got)
+		}
+	}
+	if got := multi.ReviewCard(tagged, Good, tagged.Interval); got.ParamsID != "author" {
+		t.Errorf("Expected reviews to keep ParamsID, but got %q", got.ParamsID)
+	}
+}
+
+func TestAdoptCards(t *testing.T) {
+	config := DefaultSchedulerConfig()
+	config.EnableFuzzing = false
+	config.DesiredRetention = 0.85
+	user, _ := NewScheduler(config, testRand)
+	config.Parameters = defaultParameters(FSRS5)
+	author, _ := NewScheduler(config, testRand)
+
+	var cards []Card
+	for i, stability := range []float64{3, 12, 40, 150} {
+		interval := author.CalculateNextReviewInterval(stability)
+		cards = append(cards, Card{CardID: int64(i + 1), State: Review, Stability: stability, Difficulty: 5, Interval: interval, ParamsID: "author"})
+	}
+	learning := Card{CardID: 9, State: Learning, Stability: 2, Difficulty: 5, Interval: 10 * time.Minute}
+	cards = append(cards, learning)
+
+	adopted := AdoptCards(cards, author, user)
+	jumped := false
+	for i, card := range adopted[:4] {
+		if diff := (user.CalculateNextReviewInterval(card.Stability) - cards[i].Interval).Abs(); diff > dayDuration {
+			t.Errorf("Card %d: expected a continuous interval near %v, but got %v", card.CardID, cards[i].Interval, user.CalculateNextReviewInterval(card.Stability))
+		}
+		if r := user.retrievability(card.Stability, card.Interval); math.Abs(r-author.retrievability(cards[i].Stability, cards[i].Interval)) > 1e-9 {
+			t.Errorf("Card %d: expected retrievability at due to be kept, but got %v", card.CardID, r)
+		}
+		if (user.CalculateNextReviewInterval(cards[i].Stability) - cards[i].Interval).Abs() > dayDuration {
+			jumped = true
+		}
+	}
+	if !jumped {
+		t.Errorf("Expected the unadopted stabilities to jump under the new weights")
+	}
+	if adopted[4] != learning {
+		t.Errorf("Expected a learning card to be left unchanged, but got %+v", adopted[4])
+	}
+	if cards[0].Stability != 3 {
+		t.Errorf("Expected the input cards to be left unchanged")
+	}
+}
+
+func TestRescaleStabilities(t *testing.T) {
+	config := DefaultSchedulerConfig()
+	config.EnableFuzzing = false
+	from, _ := NewScheduler(config, testRand)
+	config.Parameters = defaultParameters(FSRS5)
+	config.DesiredRetention = 0.85
+	to, _ := NewScheduler(config, testRand)
+
+	var cards []Card
+	for i, stability := range []float64{1.5, 4, 10, 33, 120, 400, 2000} {
+		cards = append(cards, Card{CardID: int64(i + 1), State: Review, Stability: stability, Difficulty: float64(3 + i%5), Interval: from.CalculateNextReviewInterval(stability)})
+	}
+	cards = append(cards, NewCard(99))
+
+	rescaled := RescaleStabilities(cards, from, to)
+	for i, card := range rescaled[:len(rescaled)-1] {
+		expected := from.CalculateNextReviewInterval(cards[i].Stability)
+		if got := to.CalculateNextReviewInterval(card.Stability); (got - expected).Abs() > dayDuration {
+			t.Errorf("Card %d: expected the interval to stay at %v within rounding, but got %v", card.CardID, expected, got)
+		}
+		if card.Interval != cards[i].Interval {
+			t.Errorf("Card %d: expected the current interval to be kept", card.CardID)
+		}
+	}
+	if rescaled[len(rescaled)-1] != NewCard(99) {
+		t.Errorf("Expected a new card to be left unchanged")
+	}
+	if to.CalculateNextReviewInterval(cards[4].Stability) == from.CalculateNextReviewInterval(cards[4].Stability) {
+		t.Errorf("Expected the raw stabilities to schedule differently under the new scheduler")
+	}
+}
@@ -0,0 +1,23 @@
+package fsrs
+
+import "time"
+
+func AnkiDefaults() SchedulerConfig {
+	return DefaultSchedulerConfig()
+}
+
+func IntensiveExamPrep() SchedulerConfig {
+	config := DefaultSchedulerConfig()
+	config.DesiredRetention = 0.95
+	config.LearningSteps = []time.Duration{time.Minute, 5 * time.Minute, 15 * time.Minute}
+	config.RelearningSteps = []time.Duration{5 * time.Minute, 15 * time.Minute}
+	config.MaximumInterval = 90
+	return config
+}
+
+func LowWorkload() SchedulerConfig {
+	config := DefaultSchedulerConfig()
+	config.DesiredRetention = 0.8
+	config.LearningSteps = []time.Duration{10 * time.Minute}
+	return config
+}
@@ -0,0 +1,97 @@
+package fsrs
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPresetsAreValid(t *testing.T) {
+	presets := map[string]SchedulerConfig{
+		"AnkiDefaults":      AnkiDefaults(),
+		"IntensiveExamPrep": IntensiveExamPrep(),
+		"LowWorkload":       LowWorkload(),
+	}
+
+	for name, config := range presets {
+		if _, err := NewScheduler(config, testRand); err != nil {
+			t.Errorf("Preset %s is invalid: %v", name, err)
+		}
+		if config.DesiredRetention <= 0 || config.DesiredRetention >= 1 {
+			t.Errorf("Preset %s has retention %v outside (0, 1)", name, config.DesiredRetention)
+		}
+	}
+}
+
+func TestAnkiDefaults(t *testing.T) {
+	config := AnkiDefaults()
+	expectedSteps := []time.Duration{time.Minute, 10 * time.Minute}
+
+	if len(config.LearningSteps) != len(expectedSteps) {
+		t.Fatalf("Expected learning steps %v, but got %v", expectedSteps, config.LearningSteps)
+	}
+	for i, step := range expectedSteps {
+		if config.LearningSteps[i] != step {
+			t.Errorf("Expected learning steps %v, but got %v", expectedSteps, config.LearningSteps)
+		}
+	}
+	if config.DesiredRetention != 0.9 {
+		t.Errorf("Expected retention 0.9, but got %v", config.DesiredRetention)
+	}
+	if !config.EnableFuzzing {
+		t.Errorf("Expected fuzzing to be enabled")
+	}
+}
+
+func TestIntensiveExamPrep(t *testing.T) {
+	anki, _ := NewScheduler(AnkiDefaults(), testRand)
+	intensiveConfig := IntensiveExamPrep()
+	intensive, _ := NewScheduler(intensiveConfig, testRand)
+
+	if intensiveConfig.DesiredRetention <= AnkiDefaults().DesiredRetention {
+		t.Errorf("Expected retention above %v, but got %v", AnkiDefaults().DesiredRetention, intensiveConfig.DesiredRetention)
+	}
+	if intensiveConfig.MaximumInterval >= AnkiDefaults().MaximumInterval {
+		t.Errorf("Expected maximum interval below %v, but got %v", AnkiDefaults().MaximumInterval, intensiveConfig.MaximumInterval)
+	}
+
+	stability := 10.0
+	if intensive.CalculateNextReviewInterval(stability) >= anki.CalculateNextReviewInterval(stability) {
+		t.Errorf("Expected intensive interval to be shorter than default interval")
+	}
+	if intensive.CalculateNextReviewInterval(10000) > time.Duration(intensiveConfig.MaximumInterval)*dayDuration {
+		t.Errorf("Expected intensive interval to be capped at %v days", intensiveConfig.MaximumInterval)
+	}
+}
+
+func TestLowWorkload(t *testing.T) {
+	anki, _ := NewScheduler(AnkiDefaults(), testRand)
+	low, _ := NewScheduler(LowWorkload(), testRand)
+
+	if LowWorkload().DesiredRetention != 0.8 {
+		t.Errorf("Expected retention 0.8, but got %v", LowWorkload().DesiredRetention)
+	}
+
+	for _, stability := range []float64{1, 5, 20, 100} {
+		ankiInterval := anki.CalculateNextReviewInterval(stability)
+		lowInterval := low.CalculateNextReviewInterval(stability)
+		if float64(lowInterval) < 1.5*float64(ankiInterval) {
+			t.Errorf("Expected low workload interval %v to be at least 1.5x %v for stability %v", lowInterval, ankiInterval, stability)
+		}
+	}
+}
+
+func TestPresetsReturnFreshCopies(t *testing.T) {
+	presets := []func() SchedulerConfig{AnkiDefaults, IntensiveExamPrep, LowWorkload}
+
+	for _, preset := range presets {
+		first := preset()
+		first.Parameters[0] = 100
+		first.LearningSteps[0] = time.Hour
+		first.RelearningSteps[0] = time.Hour
+
+		second := preset()
+		if second.Parameters[0] == 100 || second.LearningSteps[0] == time.Hour || second.RelearningSteps[0] == time.Hour {
+			t.Errorf("Expected preset to return a fresh copy, but mutation leaked: %+v", second)
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package fsrs
+
+import "time"
+
+type PreviewRow struct {
+	Rating         Rating
+	Interval       time.Duration
+	State          State
+	StabilityAfter float64
+}
+
+// PreviewTable returns one row per rating in Ratings order.
+func (s *Scheduler) PreviewTable(card Card, elapsed time.Duration) []PreviewRow {
+	rows := make([]PreviewRow, 0, 4)
+	for _, rating := range Ratings() {
+		next := s.previewCard(card, rating, elapsed)
+		rows = append(rows, PreviewRow{
+			Rating:         rating,
+			Interval:       next.Interval,
+			State:          next.State,
+			StabilityAfter: next.Stability,
+		})
+	}
+	return rows
+}
+
+func (s *Scheduler) previewCard(card Card, rating Rating, elapsed time.Duration) Card {
+	reviewedCard := s.calculateInitialReviewedCard(card, rating, elapsed)
+	return s.determineNextPhaseAndInterval(reviewedCard, rating)
+}
+
+func (s *Scheduler) PreviewFuzzRanges(card Card, elapsed time.Duration) map[Rating][2]time.Duration {
+	ranges := make(map[Rating][2]time.Duration, 4)
+	for _, rating := range Ratings() {
+		next := s.previewCard(card, rating, elapsed)
+		if !s.config.EnableFuzzing || next.State != Review {
+			ranges[rating] = [2]time.Duration{next.Interval, next.Interval}
+			continue
+		}
+		low, high := s.FuzzBounds(next.Interval)
+		ranges[rating] = [2]time.Duration{low, high}
+	}
+	return ranges
+}
+
+// TomorrowLoadContribution reports whether rating the card now would make
+// it due on the calendar day after now's. Fuzzing never moves an interval
+// to or from one day, so the unfuzzed interval decides.
+func (s *Scheduler) TomorrowLoadContribution(card Card, rating Rating, elapsed time.Duration, now time.Time) bool {
+	next := s.previewCard(card, rating, elapsed)
+	tomorrow := startOfDay(now).AddDate(0, 0, 1)
+	return startOfDay(now.Add(next.Interval)).Equal(tomorrow)
+}
@@ -0,0 +1,126 @@
+package fsrs
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPreviewTable(t *testing.T) {
+	scheduler := createDefaultScheduler()
+	card := Card{CardID: 1, Interval: 10 * dayDuration, Stability: 10, Difficulty: 5, State: Review}
+
+	rows := scheduler.PreviewTable(card, card.Interval)
+	if len(rows) != 4 {
+		t.Fatalf("Expected 4 rows, but got %d", len(rows))
+	}
+
+	for i, expected := range []Rating{Again, Hard, Good, Easy} {
+		if rows[i].Rating != expected {
+			t.Errorf("Expected rating %v in row %d, but got %v", expected, i, rows[i].Rating)
+		}
+	}
+
+	if rows[0].State != Relearning || rows[0].Interval != 10*time.Minute {
+		t.Errorf("Expected Again to relearn in 10 minutes, but got %v in %v", rows[0].State, rows[0].Interval)
+	}
+	for i := 1; i < len(rows); i++ {
+		if rows[i].State != Review {
+			t.Errorf("Expected %v to keep the card in review, but got %v", rows[i].Rating, rows[i].State)
+		}
+		if rows[i].Interval < rows[i-1].Interval {
+			t.Errorf("Expected intervals in ascending order, but got %v after %v", rows[i].Interval, rows[i-1].Interval)
+		}
+		if rows[i].StabilityAfter < rows[i-1].StabilityAfter {
+			t.Errorf("Expected stability in ascending order, but got %v after %v", rows[i].StabilityAfter, rows[i-1].StabilityAfter)
+		}
+		if rows[i].Interval != scheduler.CalculateNextReviewInterval(rows[i].StabilityAfter) {
+			t.Errorf("Expected interval to match stability for %v, but got %v", rows[i].Rating, rows[i].Interval)
+		}
+	}
+}
+
+func TestPreviewTableDoesNotConsumeRandomness(t *testing.T) {
+	config := DefaultSchedulerConfig()
+	scheduler, _ := NewScheduler(config, nil)
+	card := Card{CardID: 1, Interval: 30 * dayDuration, Stability: 30, Difficulty: 5, State: Review}
+
+	first := scheduler.PreviewTable(card, card.Interval)
+	second := scheduler.PreviewTable(card, card.Interval)
+	for i := range first {
+		if first[i] != second[i] {
+			t.Errorf("Expected identical previews, but got %+v and %+v", first[i], second[i])
+		}
+	}
+}
+
+func TestFuzzBounds(t *testing.T) {
+	scheduler := createDefaultScheduler()
+
+	low, high := scheduler.FuzzBounds(2 * dayDuration)
+	if low != 2*dayDuration || high != 2*dayDuration {
+		t.Errorf("Expected no fuzz below 2.5 days, but got %v-%v", low, high)
+	}
+
+	for range 200 {
+		interval := 30 * dayDuration
+		fuzzed := getFuzzedInterval(testRand, scheduler.config.MaximumInterval, interval)
+		low, high := scheduler.FuzzBounds(interval)
+		if fuzzed < low || fuzzed > high {
+			t.Fatalf("Expected fuzzed interval within %v-%v, but got %v", low, high, fuzzed)
+		}
+	}
+}
+
+func TestPreviewFuzzRanges(t *testing.T) {
+	scheduler := createDefaultScheduler()
+	young := Card{CardID: 1, Interval: 5 * dayDuration, Stability: 5, Difficulty: 5, State: Review}
+	mature := Card{CardID: 2, Interval: 60 * dayDuration, Stability: 60, Difficulty: 5, State: Review}
+
+	youngRanges := scheduler.PreviewFuzzRanges(young, young.Interval)
+	matureRanges := scheduler.PreviewFuzzRanges(mature, mature.Interval)
+
+	if len(youngRanges) != 4 {
+		t.Fatalf("Expected 4 ranges, but got %d", len(youngRanges))
+	}
+	for _, card := range []map[Rating][2]time.Duration{youngRanges, matureRanges} {
+		again := card[Again]
+		if again[0] != again[1] || again[0] != 10*time.Minute {
+			t.Errorf("Expected zero-width Again range at 10 minutes, but got %v", again)
+		}
+		for _, rating := range []Rating{Hard, Good, Easy} {
+			if card[rating][0] > card[rating][1] {
+				t.Errorf("Expected ordered range for %v, but got %v", rating, card[rating])
+			}
+		}
+	}
+
+	youngWidth := youngRanges[Good][1] - youngRanges[Good][0]
+	matureWidth := matureRanges[Good][1] - matureRanges[Good][0]
+	if matureWidth <= youngWidth {
+		t.Errorf("Expected wider range for longer intervals, but got %v vs %v", matureWidth, youngWidth)
+	}
+}
+
+func TestTomorrowLoadContribution(t *testing.T) {
+	scheduler := createDefaultScheduler()
+	noon := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	learning := Card{CardID: 1, State: Learning, Step: 1, Interval: 10 * time.Minute, Stability: 0.5, Difficulty: 7}
+	if !scheduler.TomorrowLoadContribution(learning, Good, 10*time.Minute, noon) {
+		t.Errorf("Expected a short Good interval to land tomorrow")
+	}
+
+	mature := Card{CardID: 2, State: Review, Interval: 40 * dayDuration, Stability: 50, Difficulty: 5}
+	if scheduler.TomorrowLoadContribution(mature, Good, 40*dayDuration, noon) {
+		t.Errorf("Expected a long Good interval not to land tomorrow")
+	}
+
+	fresh := NewCard(3)
+	if scheduler.TomorrowLoadContribution(fresh, Good, 0, noon) {
+		t.Errorf("Expected a learning step later today not to land tomorrow")
+	}
+	lateEvening := time.Date(2025, 3, 10, 23, 55, 0, 0, time.UTC)
+	if !scheduler.TomorrowLoadContribution(fresh, Good, 0, lateEvening) {
+		t.Errorf("Expected a learning step past midnight to land tomorrow")
+	}
+}
@@ -0,0 +1,109 @@
+package pyfsrs
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	fsrs "fsrs-go"
+)
+
+const isoLayout = "2006-01-02T15:04:05-07:00"
+const isoLayoutMicro = "2006-01-02T15:04:05.000000-07:00"
+
+type cardDict struct {
+	CardID     int64    `json:"card_id"`
+	State      int      `json:"state"`
+	Step       *int     `json:"step"`
+	Stability  *float64 `json:"stability"`
+	Difficulty *float64 `json:"difficulty"`
+	Due        string   `json:"due"`
+	LastReview *string  `json:"last_review"`
+}
+
+func MarshalPyFSRS(card fsrs.Card, due, lastReview time.Time) ([]byte, error) {
+	dict := cardDict{
+		CardID: card.CardID,
+		Due:    formatISO(due),
+	}
+
+	switch card.State {
+	case fsrs.New:
+		step := 0
+		dict.State = int(fsrs.Learning)
+		dict.Step = &step
+	case fsrs.Learning, fsrs.Relearning:
+		step := card.Step
+		dict.State = int(card.State)
+		dict.Step = &step
+		dict.Stability = &card.Stability
+		dict.Difficulty = &card.Difficulty
+	case fsrs.Review:
+		dict.State = int(card.State)
+		dict.Stability = &card.Stability
+		dict.Difficulty = &card.Difficulty
+	default:
+		return nil, fmt.Errorf("invalid card state %d", card.State)
+	}
+
+	if card.State != fsrs.New && !lastReview.IsZero() {
+		formatted := formatISO(lastReview)
+		dict.LastReview = &formatted
+	}
+
+	return json.Marshal(dict)
+}
+
+func UnmarshalPyFSRS(data []byte) (card fsrs.Card, due, lastReview time.Time, err error) {
+	var dict cardDict
+	if err = json.Unmarshal(data, &dict); err != nil {
+		return fsrs.Card{}, time.Time{}, time.Time{}, err
+	}
+
+	if due, err = time.Parse(time.RFC3339Nano, dict.Due); err != nil {
+		return fsrs.Card{}, time.Time{}, time.Time{}, fmt.Errorf("invalid due: %w", err)
+	}
+	due = due.UTC()
+	if dict.LastReview != nil {
+		if lastReview, err = time.Parse(time.RFC3339Nano, *dict.LastReview); err != nil {
+			return fsrs.Card{}, time.Time{}, time.Time{}, fmt.Errorf("invalid last_review: %w", err)
+		}
+		lastReview = lastReview.UTC()
+	}
+
+	card = fsrs.NewCard(dict.CardID)
+
+	state := fsrs.State(dict.State)
+	switch state {
+	case fsrs.Learning, fsrs.Relearning, fsrs.Review:
+	default:
+		return fsrs.Card{}, time.Time{}, time.Time{}, fmt.Errorf("invalid state %d", dict.State)
+	}
+
+	if dict.Stability == nil || dict.Difficulty == nil {
+		if state != fsrs.Learning {
+			return fsrs.Card{}, time.Time{}, time.Time{}, fmt.Errorf("missing memory state for state %d", dict.State)
+		}
+		return card, due, lastReview, nil
+	}
+
+	card.State = state
+	card.Stability = *dict.Stability
+	card.Difficulty = *dict.Difficulty
+	if dict.Step != nil && state != fsrs.Review {
+		card.Step = *dict.Step
+	}
+	if !lastReview.IsZero() {
+		card.Interval = due.Sub(lastReview)
+		card.LastReview = lastReview
+	}
+
+	return card, due, lastReview, nil
+}
+
+func formatISO(t time.Time) string {
+	if t.Nanosecond()/int(time.Microsecond) == 0 {
+		return t.Format(isoLayout)
+	}
+	return t.Format(isoLayoutMicro)
+}
@@ -0,0 +1,155 @@
+package pyfsrs
+
+import (
+	"encoding/json"
+	"math"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	fsrs "fsrs-go"
+)
+
+func TestUnmarshalFixtures(t *testing.T) {
+	cases := []struct {
+		file               string
+		expected           fsrs.Card
+		expectedDue        time.Time
+		expectedLastReview time.Time
+	}{
+		{
+			file:        "new_card.json",
+			expected:    fsrs.NewCard(1730549400123),
+			expectedDue: time.Date(2024, 11, 2, 12, 10, 0, 123456000, time.UTC),
+		},
+		{
+			file: "learning_card.json",
+			expected: fsrs.Card{
+				CardID:     1730549400124,
+				Interval:   10 * time.Minute,
+				Stability:  2.3065,
+				Difficulty: 2.118103970459016,
+				State:      fsrs.Learning,
+				Step:       1,
+				LastReview: time.Date(2024, 11, 2, 12, 10, 0, 0, time.UTC),
+			},
+			expectedDue:        time.Date(2024, 11, 2, 12, 20, 0, 0, time.UTC),
+			expectedLastReview: time.Date(2024, 11, 2, 12, 10, 0, 0, time.UTC),
+		},
+		{
+			file: "review_card.json",
+			expected: fsrs.Card{
+				CardID:     1730549400125,
+				Interval:   8 * 24 * time.Hour,
+				Stability:  8.2956,
+				Difficulty: 1.0,
+				State:      fsrs.Review,
+				LastReview: time.Date(2024, 11, 2, 12, 20, 0, 0, time.UTC),
+			},
+			expectedDue:        time.Date(2024, 11, 10, 12, 20, 0, 0, time.UTC),
+			expectedLastReview: time.Date(2024, 11, 2, 12, 20, 0, 0, time.UTC),
+		},
+	}
+
+	for _, c := range cases {
+		data := readFixture(t, c.file)
+		card, due, lastReview, err := UnmarshalPyFSRS(data)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", c.file, err)
+		}
+		if card != c.expected {
+			t.Errorf("%s: expected card %+v, but got %+v", c.file, c.expected, card)
+		}
+		if !due.Equal(c.expectedDue) {
+			t.Errorf("%s: expected due %v, but got %v", c.file, c.expectedDue, due)
+		}
+		if !lastReview.Equal(c.expectedLastReview) {
+			t.Errorf("%s: expected last review %v, but got %v", c.file, c.expectedLastReview, lastReview)
+		}
+	}
+}
+
+func TestMarshalRoundTripsFixtures(t *testing.T) {
+	for _, file := range []string{"new_card.json", "learning_card.json", "review_card.json", "synthetic/relearning_card.json"} {
+		data := readFixture(t, file)
+		card, due, lastReview, err := UnmarshalPyFSRS(data)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", file, err)
+		}
+
+		marshaled, err := MarshalPyFSRS(card, due, lastReview)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", file, err)
+		}
+
+		var expected, actual map[string]any
+		if err := json.Unmarshal(data, &expected); err != nil {
+			t.Fatal(err)
+		}
+		if err := json.Unmarshal(marshaled, &actual); err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(expected, actual) {
+			t.Errorf("%s: expected %v, but got %v", file, expected, actual)
+		}
+	}
+}
+
+// The relearning fixture was not captured from py-fsrs. It is the review
+// fixture rated Again when due, computed with the Python port in this
+// repository, so it checks that unmarshaling agrees with this package's own
+// lapse rather than library parity.
+func TestUnmarshalSyntheticRelearningFixture(t *testing.T) {
+	card, due, lastReview, err := UnmarshalPyFSRS(readFixture(t, "synthetic/relearning_card.json"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	review, _, reviewLastReview, err := UnmarshalPyFSRS(readFixture(t, "review_card.json"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	config := fsrs.DefaultSchedulerConfig()
+	config.EnableFuzzing = false
+	scheduler, _ := fsrs.NewScheduler(config, nil)
+	result, err := scheduler.ReviewAt(review, fsrs.Again, reviewLastReview.Add(review.Interval))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	lapsed := result.Card
+
+	if card.State != fsrs.Relearning || card.Step != 0 || card.Interval != lapsed.Interval {
+		t.Errorf("Expected %+v, but got %+v", lapsed, card)
+	}
+	if math.Abs(card.Stability-lapsed.Stability) > 1e-9 || math.Abs(card.Difficulty-lapsed.Difficulty) > 1e-9 {
+		t.Errorf("Expected stability %v and difficulty %v, but got %v and %v", lapsed.Stability, lapsed.Difficulty, card.Stability, card.Difficulty)
+	}
+	if !lastReview.Equal(lapsed.LastReview) || !due.Equal(lapsed.Due()) {
+		t.Errorf("Expected last review %v and due %v, but got %v and %v", lapsed.LastReview, lapsed.Due(), lastReview, due)
+	}
+}
+
+func TestUnmarshalRejectsInvalidData(t *testing.T) {
+	inputs := []string{
+		`{"card_id": 1, "state": 0, "step": 0, "stability": null, "difficulty": null, "due": "2024-11-02T12:10:00+00:00", "last_review": null}`,
+		`{"card_id": 1, "state": 2, "step": null, "stability": null, "difficulty": null, "due": "2024-11-02T12:10:00+00:00", "last_review": null}`,
+		`{"card_id": 1, "state": 1, "step": 0, "stability": null, "difficulty": null, "due": "yesterday", "last_review": null}`,
+		`not json`,
+	}
+
+	for _, input := range inputs {
+		if _, _, _, err := UnmarshalPyFSRS([]byte(input)); err == nil {
+			t.Errorf("Expected error for %s", input)
+		}
+	}
+}
+
+func readFixture(t *testing.T, name string) []byte {
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatalf("failed to read fixture %s: %v", name, err)
+	}
+	return data
+}
@@ -0,0 +1,49 @@
+package fsrs
+
+import "fmt"
+
+// ReadinessThresholds are the minimum amounts of data OptimizerReadiness
+// asks for before fitting parameters. Rating diversity is measured with
+// RatingEntropy.
+type ReadinessThresholds struct {
+	MinReviews       int
+	MinCards         int
+	MinRatingEntropy float64
+}
+
+func DefaultReadinessThresholds() ReadinessThresholds {
+	return ReadinessThresholds{
+		MinReviews:       400,
+		MinCards:         50,
+		MinRatingEntropy: 0.5,
+	}
+}
+
+// OptimizerReadiness checks logs against DefaultReadinessThresholds.
+func OptimizerReadiness(logs []ReviewLog) (ready bool, reason string) {
+	return OptimizerReadinessWith(logs, DefaultReadinessThresholds())
+}
+
+// OptimizerReadinessWith reports whether logs hold enough graded reviews,
+// of enough cards, with varied enough ratings to fit parameters, and
+// otherwise explains the first shortfall.
+func OptimizerReadinessWith(logs []ReviewLog, thresholds ReadinessThresholds) (ready bool, reason string) {
+	cardIDs, grouped := logsByCard(logs)
+	reviews := 0
+	var graded []ReviewLog
+	for _, cardID := range cardIDs {
+		reviews += len(grouped[cardID])
+		graded = append(graded, grouped[cardID]...)
+	}
+
+	if reviews < thresholds.MinReviews {
+		return false, fmt.Sprintf("only %d reviews, at least %d are needed", reviews, thresholds.MinReviews)
+	}
+	if len(cardIDs) < thresholds.MinCards {
+		return false, fmt.Sprintf("only %d cards reviewed, at least %d are needed", len(cardIDs), thresholds.MinCards)
+	}
+	if entropy := RatingEntropy(graded); entropy < thresholds.MinRatingEntropy {
+		return false, fmt.Sprintf("ratings are too uniform (entropy %.2f bits, at least %.2f needed)", entropy, thresholds.MinRatingEntropy)
+	}
+	return true, "enough review data to optimize"
+}
@@ -0,0 +1,39 @@
+package fsrs
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestOptimizerReadiness(t *testing.T) {
+	start := time.Date(2025, 1, 1, 9, 0, 0, 0, time.UTC)
+	build := func(cards, perCard int, rating func(i int) Rating) []ReviewLog {
+		var logs []ReviewLog
+		for id := range cards {
+			for i := range perCard {
+				logs = append(logs, ReviewLog{CardID: int64(id + 1), Rating: rating(id + i), ReviewTime: start.AddDate(0, 0, i)})
+			}
+		}
+		return logs
+	}
+	varied := func(i int) Rating { return Ratings()[i%4] }
+
+	if ready, reason := OptimizerReadiness(build(10, 5, varied)); ready || !strings.Contains(reason, "50 reviews") {
+		t.Errorf("Expected sparse logs not to be ready because of the review count, but got %v %q", ready, reason)
+	}
+	if ready, reason := OptimizerReadiness(build(20, 30, varied)); ready || !strings.Contains(reason, "20 cards") {
+		t.Errorf("Expected too few cards not to be ready, but got %v %q", ready, reason)
+	}
+	if ready, reason := OptimizerReadiness(build(100, 8, func(int) Rating { return Good })); ready || !strings.Contains(reason, "uniform") {
+		t.Errorf("Expected uniform ratings not to be ready, but got %v %q", ready, reason)
+	}
+	if ready, reason := OptimizerReadiness(build(100, 8, varied)); !ready {
+		t.Errorf("Expected rich logs to be ready, but got %q", reason)
+	}
+
+	relaxed := ReadinessThresholds{MinReviews: 10, MinCards: 5, MinRatingEntropy: 0}
+	if ready, reason := OptimizerReadinessWith(build(10, 5, varied), relaxed); !ready {
+		t.Errorf("Expected custom thresholds to be used, but got %q", reason)
+	}
+}
@@ -0,0 +1,258 @@
+package fsrs
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+var ErrInvalidRating = errors.New("invalid rating")
+var ErrInvalidEntryStep = errors.New("invalid entry step")
+var ErrClockSkew = errors.New("review time precedes last review")
+var ErrInvalidImportance = errors.New("invalid importance")
+var ErrInvalidFirstReviewDelay = errors.New("invalid first review delay")
+var ErrDeferLimit = errors.New("defer limit reached")
+
+const (
+	minImportance = 0.5
+	maxImportance = 2.0
+)
+
+type Transition struct {
+	From State
+	To   State
+}
+
+type ReviewResult struct {
+	Card           Card
+	Log            ReviewLog
+	Transition     Transition
+	Retrievability float64
+	FuzzDelta      time.Duration
+	SkewAdjustment time.Duration
+}
+
+type ReviewOption func(*reviewOptions)
+
+type reviewOptions struct {
+	reviewTime    time.Time
+	meta          map[string]string
+	entryStep     int
+	entryStepSet  bool
+	importance    float64
+	importanceSet bool
+	firstDelay    time.Duration
+}
+
+// WithReviewTime records the review time in the log and as the card's
+// LastReview.
+func WithReviewTime(reviewTime time.Time) ReviewOption {
+	return func(o *reviewOptions) {
+		o.reviewTime = reviewTime
+	}
+}
+
+func WithMeta(meta map[string]string) ReviewOption {
+	return func(o *reviewOptions) {
+		o.meta = meta
+	}
+}
+
+// WithEntryStep starts a New card, or a Review card lapsing into
+// relearning, at the given step instead of the first one.
+func WithEntryStep(step int) ReviewOption {
+	return func(o *reviewOptions) {
+		o.entryStep = step
+		o.entryStepSet = true
+	}
+}
+
+// WithImportance scales the card's forgetting target for this review only:
+// the scheduled interval aims for a lapse probability of (1-R)/importance,
+// where R is the configured desired retention. Importance must lie in
+// [0.5, 2].
+func WithImportance(importance float64) ReviewOption {
+	return func(o *reviewOptions) {
+		o.importance = importance
+		o.importanceSet = true
+	}
+}
+
+// WithFirstReviewDelay records how long a New card existed before its first
+// review, such as when reconstructing history from partial data. FSRS
+// gives the first review its initial memory state whatever the delay, so
+// the delay only appears as the log's Elapsed time, where optimizers read
+// it. The option is ignored for cards that are not New.
+func WithFirstReviewDelay(delay time.Duration) ReviewOption {
+	return func(o *reviewOptions) {
+		o.firstDelay = delay
+	}
+}
+
+// Review rates the card after elapsed time since its previous review.
+// Pass the actual elapsed time rather than the scheduled Interval; the two
+// differ whenever a review is early, late or fuzzed.
+func (s *Scheduler) Review(card Card, rating Rating, elapsed time.Duration, opts ...ReviewOption) (ReviewResult, error) {
+	if rating < Again || rating > Easy {
+		return ReviewResult{}, fmt.Errorf("%w: %d", ErrInvalidRating, rating)
+	}
+
+	var options reviewOptions
+	if len(opts) > 0 {
+		options = collectReviewOptions(opts)
+	}
+
+	if options.entryStepSet {
+		if err := s.checkEntryStep(card, options.entryStep); err != nil {
+			return ReviewResult{}, err
+		}
+	}
+	if options.firstDelay < 0 {
+		return ReviewResult{}, fmt.Errorf("%w: %v", ErrInvalidFirstReviewDelay, options.firstDelay)
+	}
+	if card.State == New && options.firstDelay > 0 {
+		elapsed = options.firstDelay
+	}
+	if options.importanceSet {
+		if !(options.importance >= minImportance && options.importance <= maxImportance) {
+			return ReviewResult{}, fmt.Errorf("%w: %v must be between %v and %v", ErrInvalidImportance, options.importance, minImportance, maxImportance)
+		}
+		s = s.withImportance(options.importance)
+	}
+
+	var retrievability float64
+	if card.State != New {
+		retrievability = s.retrievability(card.Stability, elapsed)
+	}
+
+	reviewedCard := s.calculateInitialReviewedCard(card, rating, elapsed)
+	if options.entryStepSet && card.State == New {
+		reviewedCard.Step = options.entryStep
+	}
+	cardWithNextState := s.determineNextPhaseAndInterval(reviewedCard, rating)
+	if options.entryStepSet && card.State == Review && cardWithNextState.State == Relearning {
+		cardWithNextState.Step = options.entryStep
+		cardWithNextState.Interval = s.config.RelearningSteps[options.entryStep]
+	}
+	finalCard := s.applyFuzzing(cardWithNextState)
+	finalCard.Reps++
+	finalCard.ConsecutiveDefers = 0
+	finalCard.DeferredBy = 0
+	if !options.reviewTime.IsZero() {
+		finalCard.LastReview = options.reviewTime
+	}
+
+	return ReviewResult{
+		Card: finalCard,
+		Log: ReviewLog{
+			CardID:            card.CardID,
+			Rating:            rating,
+			ReviewTime:        options.reviewTime,
+			State:             card.State,
+			ScheduledInterval: card.Interval,
+			Elapsed:           elapsed,
+			Stability:         finalCard.Stability,
+			Difficulty:        finalCard.Difficulty,
+			Meta:              options.meta,
+		},
+		Transition:     Transition{From: card.State, To: finalCard.State},
+		Retrievability: retrievability,
+		FuzzDelta:      finalCard.Interval - cardWithNextState.Interval,
+	}, nil
+}
+
+func (s *Scheduler) withImportance(importance float64) *Scheduler {
+	clone := *s
+	retention := s.desiredRetention
+	clone.config.RetentionByDifficulty = func(difficulty float64) float64 {
+		return 1.0 - (1.0-retention(difficulty))/importance
+	}
+	return &clone
+}
+
+// ReviewCardWeighted is ReviewCard with WithImportance. It returns the card
+// unchanged when importance is out of range.
+func (s *Scheduler) ReviewCardWeighted(card Card, rating Rating, elapsed time.Duration, importance float64) Card {
+	result, err := s.Review(card, rating, elapsed, WithImportance(importance))
+	if err != nil {
+		return card
+	}
+	return result.Card
+}
+
+func collectReviewOptions(opts []ReviewOption) reviewOptions {
+	var options reviewOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+	return options
+}
+
+func (s *Scheduler) checkEntryStep(card Card, step int) error {
+	var steps []time.Duration
+	switch card.State {
+	case New:
+		steps = s.config.LearningSteps
+	case Review:
+		steps = s.config.RelearningSteps
+	default:
+		return nil
+	}
+	if step < 0 || (step > 0 && step >= len(steps)) {
+		return fmt.Errorf("%w: %d for %d configured steps", ErrInvalidEntryStep, step, len(steps))
+	}
+	return nil
+}
+
+// ReviewAt rates the card at reviewTime, taking the elapsed time from its
+// LastReview. A reviewTime earlier than LastReview by at most
+// ClockSkewTolerance counts as zero elapsed time and is reported in
+// SkewAdjustment; anything earlier fails with ErrClockSkew.
+func (s *Scheduler) ReviewAt(card Card, rating Rating, reviewTime time.Time, opts ...ReviewOption) (ReviewResult, error) {
+	var elapsed, adjustment time.Duration
+	if card.State != New {
+		elapsed = reviewTime.Sub(card.LastReview)
+	}
+	if elapsed < 0 {
+		if -elapsed > s.config.ClockSkewTolerance {
+			return ReviewResult{}, fmt.Errorf("%w: card %d reviewed %v before its last review", ErrClockSkew, card.CardID, -elapsed)
+		}
+		adjustment = -elapsed
+		elapsed = 0
+	}
+
+	// Clipping opts makes append copy, so the caller's backing array is
+	// never written to.
+	result, err := s.Review(card, rating, elapsed, append(opts[:len(opts):len(opts)], WithReviewTime(reviewTime))...)
+	result.SkewAdjustment = adjustment
+	result.Log.SkewAdjustment = adjustment
+	return result, err
+}
+
+// Defer pushes the card's due date back by without touching its memory
+// state, for a "not now" action. The postponement goes to DeferredBy, so
+// Interval, and with it the next review's stability update, is unchanged.
+// The returned log has Kind LogDefer and no
+// rating; its ReviewTime is left for the caller to set. Defers are counted
+// until the next review and fail with ErrDeferLimit beyond
+// MaxConsecutiveDefers.
+func (s *Scheduler) Defer(card Card, by time.Duration) (Card, ReviewLog, error) {
+	if by <= 0 {
+		return card, ReviewLog{}, fmt.Errorf("invalid defer duration %v", by)
+	}
+	if limit := s.config.MaxConsecutiveDefers; limit > 0 && card.ConsecutiveDefers >= limit {
+		return card, ReviewLog{}, fmt.Errorf("%w: card %d deferred %d times", ErrDeferLimit, card.CardID, card.ConsecutiveDefers)
+	}
+
+	deferred := card
+	deferred.DeferredBy += by
+	deferred.ConsecutiveDefers++
+	return deferred, ReviewLog{
+		CardID:            card.CardID,
+		Kind:              LogDefer,
+		State:             card.State,
+		ScheduledInterval: card.Interval,
+		Stability:         card.Stability,
+		Difficulty:        card.Difficulty,
+	}, nil
+}
@@ -0,0 +1,482 @@
+package fsrs
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"math/rand"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// baselineCard keeps the fields Card had before Review was introduced, the
+// ones testdata/review_card_golden.json records.
+func baselineCard(c Card) Card {
+	return Card{
+		CardID:     c.CardID,
+		Interval:   c.Interval,
+		Stability:  c.Stability,
+		Difficulty: c.Difficulty,
+		State:      c.State,
+		Step:       c.Step,
+	}
+}
+
+type goldenReview struct {
+	Config   string
+	Fuzz     bool
+	Card     Card
+	Rating   Rating
+	Elapsed  time.Duration
+	Expected Card
+}
+
+// loadGoldenReviews reads ReviewCard results recorded before it became a
+// wrapper around Review, for cards in every state rated with every rating,
+// with fuzzing on and off. Each case seeds its own source with 42. The
+// dayScaleRelearning config uses relearning steps of one and three days.
+func loadGoldenReviews(t *testing.T) []goldenReview {
+	data, err := os.ReadFile("testdata/review_card_golden.json")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	var cases []goldenReview
+	if err := json.Unmarshal(data, &cases); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	return cases
+}
+
+func goldenConfig(t *testing.T, c goldenReview) SchedulerConfig {
+	config := DefaultSchedulerConfig()
+	switch c.Config {
+	case "default":
+	case "dayScaleRelearning":
+		config.RelearningSteps = []time.Duration{dayDuration, 3 * dayDuration}
+	default:
+		t.Fatalf("Unknown config %q", c.Config)
+	}
+	config.EnableFuzzing = c.Fuzz
+	return config
+}
+
+func TestReviewCardMatchesGolden(t *testing.T) {
+	for _, c := range loadGoldenReviews(t) {
+		scheduler, _ := NewScheduler(goldenConfig(t, c), rand.New(rand.NewSource(42)))
+		if got := baselineCard(scheduler.ReviewCard(c.Card, c.Rating, c.Elapsed)); got != c.Expected {
+			t.Errorf("Expected %+v for %+v rated %v after %v (%s, fuzz %v), but got %+v",
+				c.Expected, c.Card, c.Rating, c.Elapsed, c.Config, c.Fuzz, got)
+		}
+	}
+}
+
+// TestRoundDayScaleStepsDivergesFromGolden pins down where RoundDayScaleSteps
+// departs from the reference: only cards on a step of a day or more,
+// reviewed between 12 and 24 hours after it.
+func TestRoundDayScaleStepsDivergesFromGolden(t *testing.T) {
+	diverged := 0
+	for _, c := range loadGoldenReviews(t) {
+		config := goldenConfig(t, c)
+		config.RoundDayScaleSteps = true
+		scheduler, _ := NewScheduler(config, rand.New(rand.NewSource(42)))
+		got := baselineCard(scheduler.ReviewCard(c.Card, c.Rating, c.Elapsed))
+
+		onDayStep := (c.Card.State == Learning || c.Card.State == Relearning) && c.Card.Interval >= dayDuration
+		expectDiverge := onDayStep && c.Elapsed >= 12*time.Hour && c.Elapsed < dayDuration
+		if (got != c.Expected) != expectDiverge {
+			t.Errorf("Expected divergence %v for %+v rated %v after %v (%s, fuzz %v), but got %+v against %+v",
+				expectDiverge, c.Card, c.Rating, c.Elapsed, c.Config, c.Fuzz, got, c.Expected)
+		}
+		if expectDiverge {
+			diverged++
+		}
+	}
+	if diverged == 0 {
+		t.Errorf("Expected the golden cases to cover a diverging review")
+	}
+}
+
+func TestReviewCardInvalidRating(t *testing.T) {
+	scheduler := createDefaultScheduler()
+	card := Card{CardID: 1, Interval: 10 * dayDuration, Stability: 10, Difficulty: 5, State: Review}
+	for _, rating := range []Rating{0, 5} {
+		if got := scheduler.ReviewCard(card, rating, card.Interval); got != card {
+			t.Errorf("Expected rating %v to leave the card unchanged, but got %+v", rating, got)
+		}
+	}
+}
+
+func TestReviewResult(t *testing.T) {
+	config := DefaultSchedulerConfig()
+	config.EnableFuzzing = false
+	scheduler, _ := NewScheduler(config, testRand)
+	reviewTime := time.Date(2025, 4, 1, 10, 0, 0, 0, time.UTC)
+	meta := map[string]string{"tag": "verbs"}
+	card := Card{CardID: 7, Interval: 10 * dayDuration, Stability: 10, Difficulty: 5, State: Review}
+
+	result, err := scheduler.Review(card, Again, card.Interval, WithReviewTime(reviewTime), WithMeta(meta))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expectedLog := ReviewLog{
+		CardID:            7,
+		Rating:            Again,
+		ReviewTime:        reviewTime,
+		State:             Review,
+		ScheduledInterval: card.Interval,
+		Elapsed:           card.Interval,
+		Stability:         result.Card.Stability,
+		Difficulty:        result.Card.Difficulty,
+		Meta:              meta,
+	}
+	if !reflect.DeepEqual(expectedLog, result.Log) {
+		t.Errorf("Expected log %+v, but got %+v", expectedLog, result.Log)
+	}
+	if result.Transition != (Transition{From: Review, To: Relearning}) {
+		t.Errorf("Expected transition Review to Relearning, but got %+v", result.Transition)
+	}
+	if !result.Card.LastReview.Equal(reviewTime) {
+		t.Errorf("Expected last review %v, but got %v", reviewTime, result.Card.LastReview)
+	}
+	if result.Retrievability != scheduler.retrievability(card.Stability, card.Interval) {
+		t.Errorf("Expected retrievability %v, but got %v", scheduler.retrievability(card.Stability, card.Interval), result.Retrievability)
+	}
+	if result.FuzzDelta != 0 {
+		t.Errorf("Expected no fuzz delta without fuzzing, but got %v", result.FuzzDelta)
+	}
+
+	newResult, _ := scheduler.Review(NewCard(8), Good, 0)
+	if newResult.Retrievability != 0 || !newResult.Card.LastReview.IsZero() {
+		t.Errorf("Expected no retrievability or review time for a new card, but got %+v", newResult)
+	}
+}
+
+func TestReviewFuzzDelta(t *testing.T) {
+	config := DefaultSchedulerConfig()
+	fuzzed, _ := NewScheduler(config, rand.New(rand.NewSource(5)))
+	config.EnableFuzzing = false
+	plain, _ := NewScheduler(config, testRand)
+	card := Card{CardID: 1, Interval: 30 * dayDuration, Stability: 30, Difficulty: 5, State: Review}
+
+	result, _ := fuzzed.Review(card, Good, card.Interval)
+	unfuzzed := plain.ReviewCard(card, Good, card.Interval)
+	if result.Card.Interval-result.FuzzDelta != unfuzzed.Interval {
+		t.Errorf("Expected interval minus fuzz delta to be %v, but got %v", unfuzzed.Interval, result.Card.Interval-result.FuzzDelta)
+	}
+}
+
+func TestReviewRejectsInvalidRating(t *testing.T) {
+	scheduler := createDefaultScheduler()
+	card := NewCard(1)
+
+	for _, rating := range []Rating{0, 5} {
+		if _, err := scheduler.Review(card, rating, 0); !errors.Is(err, ErrInvalidRating) {
+			t.Errorf("Expected ErrInvalidRating for %d, but got %v", rating, err)
+		}
+		if reviewed := scheduler.ReviewCard(card, rating, 0); reviewed != card {
+			t.Errorf("Expected card to be unchanged for rating %d, but got %+v", rating, reviewed)
+		}
+	}
+}
+
+func TestReviewDoesNotAllocateWithoutOptions(t *testing.T) {
+	scheduler := createDefaultScheduler()
+	card := Card{CardID: 1, Interval: 30 * dayDuration, Stability: 30, Difficulty: 5, State: Review}
+
+	allocs := testing.AllocsPerRun(100, func() {
+		_, _ = scheduler.Review(card, Good, card.Interval)
+	})
+	if allocs != 0 {
+		t.Errorf("Expected no allocations, but got %v", allocs)
+	}
+}
+
+func TestWithEntryStep(t *testing.T) {
+	config := DefaultSchedulerConfig()
+	config.EnableFuzzing = false
+	config.LearningSteps = []time.Duration{time.Minute, 10 * time.Minute, time.Hour}
+	config.RelearningSteps = []time.Duration{10 * time.Minute, time.Hour}
+	scheduler, _ := NewScheduler(config, testRand)
+
+	graduated, err := scheduler.Review(NewCard(1), Good, 0, WithEntryStep(2))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if graduated.Card.State != Review || graduated.Card.Interval < dayDuration {
+		t.Errorf("Expected Good at the last entry step to graduate, but got %v with interval %v", graduated.Card.State, graduated.Card.Interval)
+	}
+
+	hard, _ := scheduler.Review(NewCard(2), Hard, 0, WithEntryStep(1))
+	if hard.Card.State != Learning || hard.Card.Step != 1 || hard.Card.Interval != 10*time.Minute {
+		t.Errorf("Expected Hard to repeat entry step 1, but got step %d interval %v", hard.Card.Step, hard.Card.Interval)
+	}
+	next := scheduler.ReviewCard(hard.Card, Good, hard.Card.Interval)
+	if next.Step != 2 || next.Interval != time.Hour {
+		t.Errorf("Expected Good to advance to step 2, but got step %d interval %v", next.Step, next.Interval)
+	}
+
+	again, _ := scheduler.Review(NewCard(3), Again, 0, WithEntryStep(2))
+	if again.Card.Step != 0 || again.Card.Interval != time.Minute {
+		t.Errorf("Expected Again to reset to the first step, but got step %d interval %v", again.Card.Step, again.Card.Interval)
+	}
+
+	mature := Card{CardID: 4, Interval: 10 * dayDuration, Stability: 10, Difficulty: 5, State: Review}
+	lapsed, _ := scheduler.Review(mature, Again, mature.Interval, WithEntryStep(1))
+	if lapsed.Card.State != Relearning || lapsed.Card.Step != 1 || lapsed.Card.Interval != time.Hour {
+		t.Errorf("Expected lapse into relearning step 1, but got %v step %d interval %v", lapsed.Card.State, lapsed.Card.Step, lapsed.Card.Interval)
+	}
+	relearned := scheduler.ReviewCard(lapsed.Card, Good, lapsed.Card.Interval)
+	if relearned.State != Review {
+		t.Errorf("Expected Good at the last relearning step to graduate, but got %v", relearned.State)
+	}
+
+	passed, _ := scheduler.Review(mature, Good, mature.Interval, WithEntryStep(1))
+	if passed.Card.State != Review || passed.Card.Step != 0 {
+		t.Errorf("Expected entry step to be ignored without a lapse, but got %v step %d", passed.Card.State, passed.Card.Step)
+	}
+}
+
+func TestWithEntryStepValidation(t *testing.T) {
+	scheduler := createDefaultScheduler()
+	mature := Card{CardID: 1, Interval: 10 * dayDuration, Stability: 10, Difficulty: 5, State: Review}
+
+	if _, err := scheduler.Review(NewCard(1), Good, 0, WithEntryStep(2)); !errors.Is(err, ErrInvalidEntryStep) {
+		t.Errorf("Expected ErrInvalidEntryStep for a learning step out of range, but got %v", err)
+	}
+	if _, err := scheduler.Review(NewCard(1), Good, 0, WithEntryStep(-1)); !errors.Is(err, ErrInvalidEntryStep) {
+		t.Errorf("Expected ErrInvalidEntryStep for a negative step, but got %v", err)
+	}
+	if _, err := scheduler.Review(mature, Again, mature.Interval, WithEntryStep(1)); !errors.Is(err, ErrInvalidEntryStep) {
+		t.Errorf("Expected ErrInvalidEntryStep for a relearning step out of range, but got %v", err)
+	}
+	if _, err := scheduler.Review(NewCard(1), Good, 0, WithEntryStep(1)); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+}
+
+func TestReviewAtFuzzedVersusPreFuzzDate(t *testing.T) {
+	scheduler, _ := NewScheduler(DefaultSchedulerConfig(), rand.New(rand.NewSource(11)))
+	card := Card{CardID: 1, Interval: 40 * dayDuration, Stability: 40, Difficulty: 5, State: Review}
+
+	var scheduled ReviewResult
+	for seed := int64(0); seed < 100 && scheduled.FuzzDelta == 0; seed++ {
+		scheduler.random = rand.New(rand.NewSource(seed))
+		scheduled, _ = scheduler.Review(card, Good, card.Interval)
+	}
+	if scheduled.FuzzDelta == 0 {
+		t.Fatalf("Expected a fuzzed interval")
+	}
+	fuzzed := scheduled.Card.Interval
+	preFuzz := fuzzed - scheduled.FuzzDelta
+
+	atFuzzed, _ := scheduler.Review(scheduled.Card, Good, fuzzed)
+	atPreFuzz, _ := scheduler.Review(scheduled.Card, Good, preFuzz)
+
+	if atFuzzed.Log.ScheduledInterval != fuzzed || atPreFuzz.Log.ScheduledInterval != fuzzed {
+		t.Errorf("Expected scheduled interval %v in both logs, but got %v and %v", fuzzed, atFuzzed.Log.ScheduledInterval, atPreFuzz.Log.ScheduledInterval)
+	}
+	if atFuzzed.Log.Elapsed != fuzzed || atPreFuzz.Log.Elapsed != preFuzz {
+		t.Errorf("Expected elapsed %v and %v, but got %v and %v", fuzzed, preFuzz, atFuzzed.Log.Elapsed, atPreFuzz.Log.Elapsed)
+	}
+	if atFuzzed.Card.Stability == atPreFuzz.Card.Stability {
+		t.Errorf("Expected elapsed time to change the resulting stability")
+	}
+	if (atFuzzed.Retrievability < atPreFuzz.Retrievability) != (fuzzed > preFuzz) {
+		t.Errorf("Expected later review to have lower retrievability, but got %v at %v and %v at %v", atFuzzed.Retrievability, fuzzed, atPreFuzz.Retrievability, preFuzz)
+	}
+}
+
+func TestReviewAt(t *testing.T) {
+	config := DefaultSchedulerConfig()
+	config.EnableFuzzing = false
+	scheduler, _ := NewScheduler(config, testRand)
+	start := time.Date(2025, 7, 1, 9, 0, 0, 0, time.UTC)
+
+	first, err := scheduler.ReviewAt(NewCard(1), Good, start)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !first.Card.LastReview.Equal(start) || first.Log.Elapsed != 0 {
+		t.Errorf("Expected first review at %v with no elapsed time, but got %v and %v", start, first.Card.LastReview, first.Log.Elapsed)
+	}
+
+	second, _ := scheduler.ReviewAt(first.Card, Good, start.Add(15*time.Minute))
+	reference := scheduler.ReviewCard(first.Card, Good, 15*time.Minute)
+	if second.Card.Stability != reference.Stability || second.Log.Elapsed != 15*time.Minute {
+		t.Errorf("Expected ReviewAt to match ReviewCard with the elapsed time")
+	}
+}
+
+func TestReviewAtClockSkew(t *testing.T) {
+	config := DefaultSchedulerConfig()
+	config.EnableFuzzing = false
+	config.ClockSkewTolerance = time.Minute
+	scheduler, _ := NewScheduler(config, testRand)
+	lastReview := time.Date(2025, 7, 1, 9, 0, 0, 0, time.UTC)
+	card := Card{CardID: 1, Interval: 10 * time.Minute, Stability: 3, Difficulty: 5, State: Learning, Step: 1, LastReview: lastReview}
+
+	skewed, err := scheduler.ReviewAt(card, Good, lastReview.Add(-30*time.Second))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if skewed.SkewAdjustment != 30*time.Second || skewed.Log.Elapsed != 0 {
+		t.Errorf("Expected a 30s adjustment to zero elapsed time, but got %v and %v", skewed.SkewAdjustment, skewed.Log.Elapsed)
+	}
+	if skewed.Card.Stability != scheduler.ReviewCard(card, Good, 0).Stability {
+		t.Errorf("Expected a skewed review to behave as a same-moment review")
+	}
+
+	if _, err := scheduler.ReviewAt(card, Good, lastReview.Add(-10*time.Minute)); !errors.Is(err, ErrClockSkew) {
+		t.Errorf("Expected ErrClockSkew beyond the tolerance, but got %v", err)
+	}
+}
+
+func TestReviewCardWeighted(t *testing.T) {
+	config := DefaultSchedulerConfig()
+	config.EnableFuzzing = false
+	scheduler, _ := NewScheduler(config, testRand)
+	card := Card{CardID: 1, State: Review, Stability: 20, Difficulty: 5, Interval: 20 * dayDuration}
+
+	normal := scheduler.ReviewCardWeighted(card, Good, card.Interval, 1)
+	important := scheduler.ReviewCardWeighted(card, Good, card.Interval, 2)
+	minor := scheduler.ReviewCardWeighted(card, Good, card.Interval, 0.5)
+
+	if normal != scheduler.ReviewCard(card, Good, card.Interval) {
+		t.Errorf("Expected importance 1 to match ReviewCard")
+	}
+	if important.Interval >= normal.Interval || minor.Interval <= normal.Interval {
+		t.Errorf("Expected intervals to shrink with importance, but got %v, %v, %v", minor.Interval, normal.Interval, important.Interval)
+	}
+	if important.Stability != normal.Stability || important.Difficulty != normal.Difficulty {
+		t.Errorf("Expected importance to leave the memory state unchanged")
+	}
+	expected := scheduler.intervalForRetention(normal.Stability, 0.95)
+	if important.Interval != expected {
+		t.Errorf("Expected importance 2 to schedule at 95%% retention (%v), but got %v", expected, important.Interval)
+	}
+
+	for _, invalid := range []float64{0, 0.1, 3, math.NaN()} {
+		if got := scheduler.ReviewCardWeighted(card, Good, card.Interval, invalid); got != card {
+			t.Errorf("Expected the card unchanged for importance %v", invalid)
+		}
+		if _, err := scheduler.Review(card, Good, card.Interval, WithImportance(invalid)); !errors.Is(err, ErrInvalidImportance) {
+			t.Errorf("Expected ErrInvalidImportance for %v, but got %v", invalid, err)
+		}
+	}
+}
+
+func TestWithFirstReviewDelay(t *testing.T) {
+	config := DefaultSchedulerConfig()
+	config.EnableFuzzing = false
+	scheduler, _ := NewScheduler(config, testRand)
+	undelayed, _ := scheduler.Review(NewCard(1), Good, 0)
+
+	for _, delay := range []time.Duration{0, dayDuration, 30 * dayDuration} {
+		result, err := scheduler.Review(NewCard(1), Good, 0, WithFirstReviewDelay(delay))
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if result.Card != undelayed.Card {
+			t.Errorf("Expected a %v delay to leave the initial memory state unchanged, but got %+v", delay, result.Card)
+		}
+		if result.Log.Elapsed != delay {
+			t.Errorf("Expected the log to record a %v delay, but got %v", delay, result.Log.Elapsed)
+		}
+	}
+
+	learning := undelayed.Card
+	result, _ := scheduler.Review(learning, Good, 10*time.Minute, WithFirstReviewDelay(30*dayDuration))
+	if result.Log.Elapsed != 10*time.Minute {
+		t.Errorf("Expected the delay to be ignored for a reviewed card, but got %v", result.Log.Elapsed)
+	}
+
+	if _, err := scheduler.Review(NewCard(2), Good, 0, WithFirstReviewDelay(-time.Hour)); !errors.Is(err, ErrInvalidFirstReviewDelay) {
+		t.Errorf("Expected ErrInvalidFirstReviewDelay, but got %v", err)
+	}
+}
+
+func TestDefer(t *testing.T) {
+	config := DefaultSchedulerConfig()
+	config.EnableFuzzing = false
+	config.MaxConsecutiveDefers = 2
+	scheduler, _ := NewScheduler(config, testRand)
+	start := time.Date(2025, 6, 1, 9, 0, 0, 0, time.UTC)
+	reviewed, _ := scheduler.ReviewAt(Card{CardID: 1, State: Review, Stability: 12.345678901, Difficulty: 6.54321, Interval: 12 * dayDuration, LastReview: start.Add(-12 * dayDuration)}, Good, start)
+	card := reviewed.Card
+
+	deferred, log, err := scheduler.Defer(card, 2*time.Hour)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if math.Float64bits(deferred.Stability) != math.Float64bits(card.Stability) || math.Float64bits(deferred.Difficulty) != math.Float64bits(card.Difficulty) ||
+		deferred.State != card.State || deferred.Step != card.Step || deferred.Reps != card.Reps {
+		t.Errorf("Expected the memory state to be untouched, but got %+v from %+v", deferred, card)
+	}
+	if !deferred.Due().Equal(card.Due().Add(2 * time.Hour)) {
+		t.Errorf("Expected the due date to move by 2h, but got %v", deferred.Due())
+	}
+	if log.Kind != LogDefer || log.Rating != 0 || log.CardID != 1 {
+		t.Errorf("Expected a defer log without a rating, but got %+v", log)
+	}
+
+	deferred, _, _ = scheduler.Defer(deferred, time.Hour)
+	if _, _, err := scheduler.Defer(deferred, time.Hour); !errors.Is(err, ErrDeferLimit) {
+		t.Errorf("Expected ErrDeferLimit after 2 defers, but got %v", err)
+	}
+	again, _ := scheduler.ReviewAt(deferred, Good, deferred.Due())
+	if again.Card.ConsecutiveDefers != 0 {
+		t.Errorf("Expected a review to reset the defer count, but got %d", again.Card.ConsecutiveDefers)
+	}
+
+	logs := []ReviewLog{reviewed.Log, log, again.Log}
+	if observed, _ := ObservedRetention(append(logs, ReviewLog{CardID: 1, Kind: LogDefer, State: Review})); observed != 1 {
+		t.Errorf("Expected defers to be excluded from retention, but got %v", observed)
+	}
+	if got, expected := ReviewEfficiency(logs), again.Card.Stability/2; got != expected {
+		t.Errorf("Expected defers to be excluded from efficiency, %v, but got %v", expected, got)
+	}
+
+	if _, _, err := scheduler.Defer(card, 0); err == nil {
+		t.Errorf("Expected an error for a zero defer")
+	}
+}
+
+func TestDeferLeavesNextReviewUnchanged(t *testing.T) {
+	config := DefaultSchedulerConfig()
+	config.EnableFuzzing = false
+	scheduler, _ := NewScheduler(config, testRand)
+	start := time.Date(2025, 6, 1, 9, 0, 0, 0, time.UTC)
+	learning, _ := scheduler.ReviewAt(NewCard(1), Good, start)
+
+	deferred, _, _ := scheduler.Defer(learning.Card, dayDuration)
+	if deferred.Interval != learning.Card.Interval || deferred.DeferredBy != dayDuration {
+		t.Errorf("Expected interval %v deferred by 24h, but got %v deferred by %v", learning.Card.Interval, deferred.Interval, deferred.DeferredBy)
+	}
+
+	reviewTime := start.Add(13 * time.Hour)
+	expected, _ := scheduler.ReviewAt(learning.Card, Good, reviewTime)
+	actual, _ := scheduler.ReviewAt(deferred, Good, reviewTime)
+	if actual.Card.Stability != expected.Card.Stability || actual.Card.Difficulty != expected.Card.Difficulty || actual.Card.Interval != expected.Card.Interval {
+		t.Errorf("Expected the deferred card to review like %+v, but got %+v", expected.Card, actual.Card)
+	}
+	if actual.Card.DeferredBy != 0 {
+		t.Errorf("Expected a review to clear DeferredBy, but got %v", actual.Card.DeferredBy)
+	}
+}
+
+func TestReviewAtLeavesOptionsUntouched(t *testing.T) {
+	scheduler := createDefaultScheduler()
+	start := time.Date(2025, 6, 1, 9, 0, 0, 0, time.UTC)
+	meta := WithMeta(map[string]string{"device": "phone"})
+	opts := make([]ReviewOption, 1, 2)
+	opts[0] = meta
+
+	scheduler.ReviewAt(NewCard(1), Good, start, opts...)
+	spare := opts[:2]
+	if spare[1] != nil {
+		t.Errorf("Expected ReviewAt not to write into the options' spare capacity")
+	}
+}
@@ -0,0 +1,156 @@
+package fsrs
+
+import (
+	"math"
+	"slices"
+	"sync"
+	"time"
+)
+
+const logLossEpsilon = 1e-6
+
+// Sensitivity reports how much perturbing one parameter changes the fit to
+// a collection: the larger absolute change in mean log-loss, and in the
+// median scheduled Review interval, of the two perturbations.
+type Sensitivity struct {
+	Index               int
+	LogLossDelta        float64
+	MedianIntervalDelta time.Duration
+}
+
+// SensitivityAnalysis scales each parameter by 1 ± perturbation in turn and
+// replays logs without fuzzing. Parameters are evaluated concurrently and
+// results are returned in parameter order.
+func SensitivityAnalysis(params []float64, logs []ReviewLog, perturbation float64) ([]Sensitivity, error) {
+	w, err := checkAndFillParameters(slices.Clone(params))
+	if err != nil {
+		return nil, err
+	}
+	evaluate := func(w []float64) (float64, time.Duration, error) {
+		config := DefaultSchedulerConfig()
+		config.Parameters = w
+		config.EnableFuzzing = false
+		scheduler, err := NewScheduler(config, nil)
+		if err != nil {
+			return 0, 0, err
+		}
+		loss, interval := scheduler.replayFit(logs)
+		return loss, interval, nil
+	}
+
+	baseLoss, baseInterval, err := evaluate(w)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]Sensitivity, len(w))
+	errs := make([]error, len(w))
+	var wg sync.WaitGroup
+	for i := range w {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i].Index = i
+			for _, sign := range []float64{1, -1} {
+				perturbed := slices.Clone(w)
+				perturbed[i] *= 1 + sign*perturbation
+				loss, interval, err := evaluate(perturbed)
+				if err != nil {
+					errs[i] = err
+					return
+				}
+				results[i].LogLossDelta = math.Max(results[i].LogLossDelta, math.Abs(loss-baseLoss))
+				results[i].MedianIntervalDelta = max(results[i].MedianIntervalDelta, (interval - baseInterval).Abs())
+			}
+		}()
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return results, nil
+}
+
+// replayFit replays each card's logs from New and returns the mean log-loss
+// of the predicted retrievability at every review after the first, and the
+// median interval of the reviews that ended in the Review state.
+func (s *Scheduler) replayFit(logs []ReviewLog) (float64, time.Duration) {
+	cardIDs, grouped := logsByCard(logs)
+	loss := 0.0
+	predictions := 0
+	var intervals []time.Duration
+	for _, cardID := range cardIDs {
+		card := NewCard(cardID)
+		for _, log := range grouped[cardID] {
+			if card.State != New {
+				p := math.Min(math.Max(s.retrievability(card.Stability, log.Elapsed), logLossEpsilon), 1-logLossEpsilon)
+				if log.Rating == Again {
+					loss -= math.Log(1 - p)
+				} else {
+					loss -= math.Log(p)
+				}
+				predictions++
+			}
+			card = s.previewCard(card, log.Rating, log.Elapsed)
+			if card.State == Review {
+				intervals = append(intervals, card.Interval)
+			}
+		}
+	}
+
+	var median time.Duration
+	if len(intervals) > 0 {
+		slices.Sort(intervals)
+		median = intervals[len(intervals)/2]
+	}
+	if predictions == 0 {
+		return 0, median
+	}
+	return loss / float64(predictions), median
+}
+
+const elasticityStep = 0.01
+
+// ParameterElasticity returns, for each parameter, the percent change in
+// the interval scheduled after an on-time Good review of a medium
+// difficulty card with the given stability, per 1% change in that
+// parameter. It takes central differences of the unrounded interval.
+// Parameters the review does not use, such as the short-term ones for a
+// review a day or more apart, come out at 0. So does the decay w[20] at a
+// desired retention of 0.9, where on-time retrievability and intervals do
+// not depend on it, and whenever OverrideDecay is set.
+func (s *Scheduler) ParameterElasticity(stability float64) []float64 {
+	const difficulty = 5.0
+	card := Card{State: Review, Stability: stability, Difficulty: difficulty}
+	elapsedDays := exactIntervalDays(s.factor, s.desiredRetention(difficulty), s.decay, s.config.MaximumInterval, s.intervalScale(), stability)
+	elapsed := time.Duration(elapsedDays * float64(dayDuration))
+
+	intervalAfter := func(scheduler *Scheduler) float64 {
+		reviewed := scheduler.calculateInitialReviewedCard(card, Good, elapsed)
+		retention := scheduler.desiredRetention(reviewed.Difficulty)
+		return exactIntervalDays(scheduler.factor, retention, scheduler.decay, scheduler.config.MaximumInterval, scheduler.intervalScale(), reviewed.Stability)
+	}
+
+	base := intervalAfter(s)
+	elasticities := make([]float64, len(s.w))
+	for i, value := range s.w {
+		up := intervalAfter(s.withParameter(i, value*(1+elasticityStep)))
+		down := intervalAfter(s.withParameter(i, value*(1-elasticityStep)))
+		elasticities[i] = (up - down) / 2 / base / elasticityStep
+	}
+	return elasticities
+}
+
+func (s *Scheduler) withParameter(index int, value float64) *Scheduler {
+	clone := *s
+	clone.w = slices.Clone(s.w)
+	clone.w[index] = value
+	if index == 20 && s.config.OverrideDecay == nil {
+		clone.decay = -value
+		clone.factor = math.Pow(0.9, 1.0/clone.decay) - 1.0
+	}
+	return &clone
+}
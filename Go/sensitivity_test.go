@@ -0,0 +1,86 @@
+package fsrs
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+	"time"
+)
+
+func TestSensitivityAnalysis(t *testing.T) {
+	scheduler := createDefaultScheduler()
+	random := rand.New(rand.NewSource(11))
+	start := time.Date(2025, 1, 1, 9, 0, 0, 0, time.UTC)
+
+	var logs []ReviewLog
+	for id := int64(1); id <= 300; id++ {
+		first := Rating(random.Intn(4) + 1)
+		card := scheduler.ReviewCard(NewCard(id), first, 0)
+		logs = append(logs, ReviewLog{CardID: id, Rating: first, State: New, ReviewTime: start})
+
+		elapsed := time.Duration(1+random.Intn(30)) * dayDuration
+		second := Good
+		if random.Float64() > scheduler.retrievability(card.Stability, elapsed) {
+			second = Again
+		}
+		logs = append(logs, ReviewLog{CardID: id, Rating: second, State: card.State, ReviewTime: start.Add(elapsed), Elapsed: elapsed})
+	}
+
+	params := DefaultSchedulerConfig().Parameters
+	results, err := SensitivityAnalysis(params, logs, 0.2)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(results) != 21 {
+		t.Fatalf("Expected 21 results, but got %d", len(results))
+	}
+
+	for i := 0; i < 4; i++ {
+		if results[i].LogLossDelta == 0 {
+			t.Errorf("Expected w[%d] to affect the log-loss of first-review histories", i)
+		}
+	}
+	for i := 4; i < 20; i++ {
+		if results[i].LogLossDelta != 0 {
+			t.Errorf("Expected w[%d] not to affect the log-loss of first-review histories, but got %v", i, results[i].LogLossDelta)
+		}
+	}
+	for i, result := range results {
+		if result.Index != i {
+			t.Errorf("Expected results in parameter order, but got index %d at %d", result.Index, i)
+		}
+	}
+
+	again, _ := SensitivityAnalysis(params, logs, 0.2)
+	for i := range results {
+		if results[i] != again[i] {
+			t.Errorf("Expected deterministic results, but got %+v and %+v", results[i], again[i])
+		}
+	}
+
+	if _, err := SensitivityAnalysis(params[:5], logs, 0.2); err == nil {
+		t.Errorf("Expected an error for an invalid parameter count")
+	}
+}
+
+func TestParameterElasticity(t *testing.T) {
+	config := DefaultSchedulerConfig()
+	config.DesiredRetention = 0.85
+	scheduler, _ := NewScheduler(config, testRand)
+
+	elasticities := scheduler.ParameterElasticity(100)
+	if len(elasticities) != 21 {
+		t.Fatalf("Expected 21 elasticities, but got %d", len(elasticities))
+	}
+	if math.Abs(elasticities[20]) < 0.01 {
+		t.Errorf("Expected non-negligible elasticity for the decay, but got %v", elasticities[20])
+	}
+	if elasticities[8] <= 0 {
+		t.Errorf("Expected the interval to grow with w[8], but got %v", elasticities[8])
+	}
+	for _, i := range []int{17, 18, 19} {
+		if math.Abs(elasticities[i]) > 1e-9 {
+			t.Errorf("Expected near zero elasticity for short-term parameter w[%d], but got %v", i, elasticities[i])
+		}
+	}
+}
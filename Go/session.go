@@ -0,0 +1,69 @@
+package fsrs
+
+import "time"
+
+// WithinSession reports whether a Learning or Relearning card comes due
+// again within the remaining session time. Review cards never do.
+func (s *Scheduler) WithinSession(card Card, sessionRemaining time.Duration) bool {
+	if card.State != Learning && card.State != Relearning {
+		return false
+	}
+	return card.Interval <= sessionRemaining
+}
+
+// DeferLearningCards splits a session's cards into those it keeps and the
+// Learning or Relearning cards whose next step falls after it ends, which
+// are left for a later session. New and Review cards are always kept, so
+// every card comes back in exactly one slice, in the given order.
+// Deferred cards keep their state; review them with ReviewAt so the
+// elapsed time reflects when they are actually seen, which moves them to
+// the long-term stability update once a day has passed.
+func (s *Scheduler) DeferLearningCards(cards []Card, sessionRemaining time.Duration) (session, deferred []Card) {
+	for _, card := range cards {
+		if (card.State == Learning || card.State == Relearning) && !s.WithinSession(card, sessionRemaining) {
+			deferred = append(deferred, card)
+		} else {
+			session = append(session, card)
+		}
+	}
+	return session, deferred
+}
+
+type SessionStrategy int
+
+const (
+	NewFirst    SessionStrategy = 0
+	NewLast     SessionStrategy = 1
+	Interleaved SessionStrategy = 2
+)
+
+// OrderSession returns the session's cards in the order the strategy
+// shows them, keeping the given order within newCards and dueCards.
+// Interleaved spreads the new cards evenly through the due ones, placing
+// each group's cards at evenly spaced fractions of the session; at equal
+// fractions the due card comes first. Unknown strategies order like
+// NewFirst.
+func (s *Scheduler) OrderSession(newCards, dueCards []Card, strategy SessionStrategy) []Card {
+	ordered := make([]Card, 0, len(newCards)+len(dueCards))
+	switch strategy {
+	case NewLast:
+		ordered = append(ordered, dueCards...)
+		return append(ordered, newCards...)
+	case Interleaved:
+		n, d := len(newCards), len(dueCards)
+		j, k := 0, 0
+		for j < n || k < d {
+			if k == d || (j < n && (2*j+1)*d < (2*k+1)*n) {
+				ordered = append(ordered, newCards[j])
+				j++
+			} else {
+				ordered = append(ordered, dueCards[k])
+				k++
+			}
+		}
+		return ordered
+	default:
+		ordered = append(ordered, newCards...)
+		return append(ordered, dueCards...)
+	}
+}
@@ -0,0 +1,99 @@
+package fsrs
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestWithinSession(t *testing.T) {
+	scheduler := createDefaultScheduler()
+	learning := Card{CardID: 1, State: Learning, Stability: 2, Difficulty: 5, Interval: 4 * time.Hour}
+	relearning := Card{CardID: 2, State: Relearning, Stability: 1, Difficulty: 7, Interval: 10 * time.Minute}
+	review := Card{CardID: 3, State: Review, Stability: 5, Difficulty: 5, Interval: 5 * dayDuration}
+
+	if scheduler.WithinSession(learning, 30*time.Minute) {
+		t.Errorf("Expected a 4 hour step not to fit a 30 minute session")
+	}
+	if !scheduler.WithinSession(learning, 4*time.Hour) {
+		t.Errorf("Expected a 4 hour step to fit a 4 hour session")
+	}
+	if !scheduler.WithinSession(relearning, 30*time.Minute) {
+		t.Errorf("Expected a 10 minute step to fit a 30 minute session")
+	}
+	if scheduler.WithinSession(review, 30*dayDuration) {
+		t.Errorf("Expected review cards never to be within the session")
+	}
+
+	session, deferred := scheduler.DeferLearningCards([]Card{learning, relearning, review, NewCard(4)}, 30*time.Minute)
+	if !reflect.DeepEqual(session, []Card{relearning, review, NewCard(4)}) || !reflect.DeepEqual(deferred, []Card{learning}) {
+		t.Errorf("Expected cards 2, 3 and 4 kept and card 1 deferred, but got %v and %v", session, deferred)
+	}
+}
+
+func TestDeferredLearningElapsed(t *testing.T) {
+	config := DefaultSchedulerConfig()
+	config.EnableFuzzing = false
+	config.LearningSteps = []time.Duration{time.Minute, 4 * time.Hour}
+	scheduler, _ := NewScheduler(config, testRand)
+	start := time.Date(2025, 3, 1, 20, 0, 0, 0, time.UTC)
+
+	first, _ := scheduler.ReviewAt(NewCard(1), Good, start)
+	_, deferred := scheduler.DeferLearningCards([]Card{first.Card}, 30*time.Minute)
+	if len(deferred) != 1 {
+		t.Fatalf("Expected the card to be deferred")
+	}
+	card := deferred[0]
+
+	sameDay, _ := scheduler.ReviewAt(card, Good, start.Add(dayDuration-time.Minute))
+	if expected := shortTermStability(scheduler.w, card.Stability, Good); sameDay.Card.Stability != expected {
+		t.Errorf("Expected a short-term update just under a day later, %v, but got %v", expected, sameDay.Card.Stability)
+	}
+	if sameDay.Log.Elapsed != dayDuration-time.Minute {
+		t.Errorf("Expected the actual elapsed time, but got %v", sameDay.Log.Elapsed)
+	}
+
+	nextDay, _ := scheduler.ReviewAt(card, Good, start.Add(dayDuration))
+	r := scheduler.retrievability(card.Stability, dayDuration)
+	if expected := nextStability(scheduler.w, card.Difficulty, card.Stability, r, Good); nextDay.Card.Stability != expected {
+		t.Errorf("Expected a long-term update a day later, %v, but got %v", expected, nextDay.Card.Stability)
+	}
+}
+
+func TestOrderSession(t *testing.T) {
+	scheduler := createDefaultScheduler()
+	newCards := []Card{NewCard(101), NewCard(102)}
+	var dueCards []Card
+	for id := int64(1); id <= 6; id++ {
+		dueCards = append(dueCards, Card{CardID: id, State: Review, Stability: 5, Difficulty: 5, Interval: 5 * dayDuration})
+	}
+	ids := func(cards []Card) []int64 {
+		var result []int64
+		for _, card := range cards {
+			result = append(result, card.CardID)
+		}
+		return result
+	}
+
+	cases := []struct {
+		strategy SessionStrategy
+		expected []int64
+	}{
+		{NewFirst, []int64{101, 102, 1, 2, 3, 4, 5, 6}},
+		{NewLast, []int64{1, 2, 3, 4, 5, 6, 101, 102}},
+		{Interleaved, []int64{1, 2, 101, 3, 4, 5, 102, 6}},
+	}
+	for _, c := range cases {
+		actual := ids(scheduler.OrderSession(newCards, dueCards, c.strategy))
+		if !reflect.DeepEqual(c.expected, actual) {
+			t.Errorf("Expected %v for strategy %v, but got %v", c.expected, c.strategy, actual)
+		}
+	}
+
+	if actual := ids(scheduler.OrderSession(nil, dueCards[:2], Interleaved)); !reflect.DeepEqual([]int64{1, 2}, actual) {
+		t.Errorf("Expected only due cards, but got %v", actual)
+	}
+	if actual := ids(scheduler.OrderSession(newCards, nil, Interleaved)); !reflect.DeepEqual([]int64{101, 102}, actual) {
+		t.Errorf("Expected only new cards, but got %v", actual)
+	}
+}
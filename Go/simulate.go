@@ -0,0 +1,138 @@
+package fsrs
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"time"
+)
+
+const (
+	matureInterval   = 21 * dayDuration
+	introductionTail = 60
+)
+
+// SimResult holds one entry per simulated day: the number of reviews, the
+// mean retrievability of reviewed cards at the end of the day as in
+// DeckRetentionScore, and the number of cards with an interval of 21 days
+// or more.
+type SimResult struct {
+	Reviews     []int
+	Retention   []float64
+	MatureCards []int
+}
+
+// SimulateDeckOverTime introduces newPerDay cards each day and reviews
+// every card on the day it falls due, overdue ones at the start of the
+// day. recallModel rates each review from the card's retrievability at
+// that moment, 0 for new cards. Randomness comes from the scheduler's
+// source, or a fixed seed for a deterministic scheduler.
+func (s *Scheduler) SimulateDeckOverTime(newPerDay, days int, recallModel func(retrievability float64, rng *rand.Rand) Rating) SimResult {
+	rng := s.random
+	if rng == nil {
+		rng = rand.New(rand.NewSource(1))
+	}
+	return s.simulateDeck(func(int) int { return newPerDay }, days, func(_ Card, retrievability float64) Rating {
+		return recallModel(retrievability, rng)
+	})
+}
+
+// PlanNewCardIntroduction returns how many of deckSize new cards to
+// introduce each day so that simulated daily reviews, including learning
+// steps, stay at or under targetDailyReviews until 60 days after the last
+// introduction. It searches for the highest steady rate that fits and
+// fails with ErrBudgetExceeded when even one card a day does not. Ratings
+// are drawn from model, or NewDefaultRatingModel when nil, with a source
+// seeded by seed.
+func PlanNewCardIntroduction(deckSize int, targetDailyReviews int, cfg SchedulerConfig, model RatingModel, seed int64) ([]int, error) {
+	if deckSize <= 0 {
+		return nil, fmt.Errorf("invalid deck size %d", deckSize)
+	}
+	if model == nil {
+		model = NewDefaultRatingModel()
+	}
+	if _, err := NewScheduler(cfg, rand.New(rand.NewSource(seed))); err != nil {
+		return nil, err
+	}
+
+	fits := func(plan []int) bool {
+		scheduler, _ := NewScheduler(cfg, rand.New(rand.NewSource(seed)))
+		rng := rand.New(rand.NewSource(seed))
+		result := scheduler.simulateDeck(func(day int) int {
+			if day < len(plan) {
+				return plan[day]
+			}
+			return 0
+		}, len(plan)+introductionTail, func(card Card, retrievability float64) Rating {
+			if card.State == New {
+				return sampleRating(model.FirstRating(), rng.Float64())
+			}
+			return sampleRating(model.Rating(retrievability), rng.Float64())
+		})
+		return slices.Max(result.Reviews) <= targetDailyReviews
+	}
+
+	if !fits(steadyIntroduction(deckSize, 1)) {
+		return nil, fmt.Errorf("%w: one new card a day needs more than %d reviews a day", ErrBudgetExceeded, targetDailyReviews)
+	}
+	low, high := 1, deckSize
+	for low < high {
+		mid := (low + high + 1) / 2
+		if fits(steadyIntroduction(deckSize, mid)) {
+			low = mid
+		} else {
+			high = mid - 1
+		}
+	}
+	return steadyIntroduction(deckSize, low), nil
+}
+
+func steadyIntroduction(deckSize, perDay int) []int {
+	plan := make([]int, 0, (deckSize+perDay-1)/perDay)
+	for remaining := deckSize; remaining > 0; remaining -= perDay {
+		plan = append(plan, min(perDay, remaining))
+	}
+	return plan
+}
+
+func (s *Scheduler) simulateDeck(newCards func(day int) int, days int, rate func(card Card, retrievability float64) Rating) SimResult {
+	start := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+	result := SimResult{
+		Reviews:     make([]int, days),
+		Retention:   make([]float64, days),
+		MatureCards: make([]int, days),
+	}
+
+	var cards []Card
+	for day := range days {
+		dayStart := start.Add(time.Duration(day) * dayDuration)
+		dayEnd := dayStart.Add(dayDuration)
+		for range newCards(day) {
+			cards = append(cards, NewCard(int64(len(cards)+1)))
+		}
+
+		for i := range cards {
+			for cards[i].State == New || cards[i].Due().Before(dayEnd) {
+				reviewTime := dayStart
+				var retrievability float64
+				if cards[i].State != New {
+					if due := cards[i].Due(); due.After(dayStart) {
+						reviewTime = due
+					}
+					retrievability = s.cardRetrievability(cards[i], reviewTime)
+				}
+				reviewed, err := s.ReviewAt(cards[i], rate(cards[i], retrievability), reviewTime)
+				if err != nil {
+					break
+				}
+				cards[i] = reviewed.Card
+				result.Reviews[day]++
+			}
+			if cards[i].Interval >= matureInterval {
+				result.MatureCards[day]++
+			}
+		}
+		result.Retention[day] = s.DeckRetentionScore(cards, dayEnd)
+	}
+	return result
+}
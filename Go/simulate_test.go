@@ -0,0 +1,96 @@
+package fsrs
+
+import (
+	"errors"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestSimulateDeckOverTime(t *testing.T) {
+	config := DefaultSchedulerConfig()
+	config.EnableFuzzing = false
+	scheduler, _ := NewScheduler(config, testRand)
+	threshold := func(retrievability float64, rng *rand.Rand) Rating {
+		if retrievability >= 0.7 {
+			return Good
+		}
+		return Again
+	}
+
+	result := scheduler.SimulateDeckOverTime(10, 120, threshold)
+	if len(result.Reviews) != 120 || len(result.Retention) != 120 || len(result.MatureCards) != 120 {
+		t.Fatalf("Expected 120 days of results, but got %d, %d, %d", len(result.Reviews), len(result.Retention), len(result.MatureCards))
+	}
+	if result.Reviews[0] < 10 {
+		t.Errorf("Expected at least one review per new card on the first day, but got %d", result.Reviews[0])
+	}
+	for day := 90; day < 120; day++ {
+		if r := result.Retention[day]; r < 0.9 || r > 0.99 {
+			t.Errorf("Day %d: expected retention to settle just above the 0.9 target, but got %v", day, r)
+		}
+	}
+	if result.MatureCards[119] <= result.MatureCards[30] {
+		t.Errorf("Expected the mature count to grow, but got %d then %d", result.MatureCards[30], result.MatureCards[119])
+	}
+
+	overdue := func(retrievability float64, rng *rand.Rand) Rating {
+		if retrievability > rng.Float64() {
+			return Good
+		}
+		return Again
+	}
+	a := scheduler.SimulateDeckOverTime(5, 60, overdue)
+	deterministic, _ := NewDeterministicScheduler(config)
+	b := deterministic.SimulateDeckOverTime(5, 60, overdue)
+	c := deterministic.SimulateDeckOverTime(5, 60, overdue)
+	if len(a.Reviews) != 60 {
+		t.Errorf("Expected 60 days, but got %d", len(a.Reviews))
+	}
+	for day := range b.Reviews {
+		if b.Reviews[day] != c.Reviews[day] {
+			t.Errorf("Expected a deterministic scheduler to repeat its simulation")
+			break
+		}
+	}
+}
+
+func TestPlanNewCardIntroduction(t *testing.T) {
+	config := DefaultSchedulerConfig()
+	plan, err := PlanNewCardIntroduction(400, 60, config, nil, 5)
+	if err != nil || len(plan) == 0 {
+		t.Fatalf("Expected a plan, but got %v", err)
+	}
+	total := 0
+	for _, n := range plan {
+		if n <= 0 {
+			t.Errorf("Expected a positive count every day, but got %v", plan)
+		}
+		total += n
+	}
+	if total != 400 {
+		t.Errorf("Expected the plan to introduce 400 cards, but got %d", total)
+	}
+	if plan[0] >= 60 {
+		t.Errorf("Expected fewer new cards a day than the review target, but got %d", plan[0])
+	}
+
+	tighter, _ := PlanNewCardIntroduction(400, 30, config, nil, 5)
+	if tighter[0] > plan[0] {
+		t.Errorf("Expected a lower target to slow introduction, but got %d vs %d", tighter[0], plan[0])
+	}
+	if again, _ := PlanNewCardIntroduction(400, 60, config, nil, 5); !slices.Equal(again, plan) {
+		t.Errorf("Expected the same seed to give the same plan")
+	}
+
+	if plan, err := PlanNewCardIntroduction(50, 1, config, nil, 5); !errors.Is(err, ErrBudgetExceeded) || plan != nil {
+		t.Errorf("Expected ErrBudgetExceeded when nothing fits, but got %v, %v", plan, err)
+	}
+	if _, err := PlanNewCardIntroduction(0, 60, config, nil, 5); err == nil {
+		t.Errorf("Expected an error for an empty deck")
+	}
+	config.IntervalScale = -1
+	if plan, err := PlanNewCardIntroduction(50, 60, config, nil, 5); err == nil || plan != nil {
+		t.Errorf("Expected an error for an invalid config, but got %v", plan)
+	}
+}
@@ -0,0 +1,221 @@
+package fsrs
+
+import (
+	"math"
+	"slices"
+	"time"
+)
+
+// RatingEntropy returns the Shannon entropy of the rating distribution in
+// bits: 0 when every review used the same rating, 2 when all four ratings
+// are equally common.
+func RatingEntropy(logs []ReviewLog) float64 {
+	var counts [4]int
+	total := 0
+	for _, log := range logs {
+		if log.Rating < Again || log.Rating > Easy {
+			continue
+		}
+		counts[log.Rating-Again]++
+		total++
+	}
+
+	entropy := 0.0
+	for _, count := range counts {
+		if count == 0 {
+			continue
+		}
+		p := float64(count) / float64(total)
+		entropy -= p * math.Log2(p)
+	}
+	return entropy
+}
+
+// logsByCard groups graded reviews by card, leaving out defers.
+func logsByCard(logs []ReviewLog) ([]int64, map[int64][]ReviewLog) {
+	grouped := make(map[int64][]ReviewLog)
+	var cardIDs []int64
+	for _, log := range logs {
+		if log.Kind != LogReview {
+			continue
+		}
+		if _, ok := grouped[log.CardID]; !ok {
+			cardIDs = append(cardIDs, log.CardID)
+		}
+		grouped[log.CardID] = append(grouped[log.CardID], log)
+	}
+	slices.Sort(cardIDs)
+	for _, cardLogs := range grouped {
+		slices.SortStableFunc(cardLogs, func(a, b ReviewLog) int {
+			return a.ReviewTime.Compare(b.ReviewTime)
+		})
+	}
+	return cardIDs, grouped
+}
+
+// GroupLogs splits logs by key, keeping the input order within each group.
+func GroupLogs(logs []ReviewLog, key func(ReviewLog) string) map[string][]ReviewLog {
+	groups := make(map[string][]ReviewLog)
+	for _, log := range logs {
+		k := key(log)
+		groups[k] = append(groups[k], log)
+	}
+	return groups
+}
+
+func ReviewTimeHistogram(logs []ReviewLog, loc *time.Location) [24]int {
+	var histogram [24]int
+	for _, log := range logs {
+		if log.Kind == LogReview {
+			histogram[log.ReviewTime.In(loc).Hour()]++
+		}
+	}
+	return histogram
+}
+
+// SessionProgress counts the distinct cards reviewed at or after
+// sessionStart, so repeated learning-step reviews of one card count once.
+func (s *Scheduler) SessionProgress(dueAtStart int, logs []ReviewLog, sessionStart time.Time) (done, remaining int) {
+	seen := make(map[int64]bool)
+	for _, log := range logs {
+		if log.Kind != LogReview || log.ReviewTime.Before(sessionStart) || seen[log.CardID] {
+			continue
+		}
+		seen[log.CardID] = true
+		done++
+	}
+	return done, max(0, dueAtStart-done)
+}
+
+// ReviewEfficiency returns the stability in days built per review: the
+// sum of each card's stability after its latest review divided by the
+// number of reviews. It relies on the post-review Stability recorded in
+// each log and returns 0 for no logs.
+func ReviewEfficiency(logs []ReviewLog) float64 {
+	cardIDs, grouped := logsByCard(logs)
+	total := 0.0
+	reviews := 0
+	for _, cardID := range cardIDs {
+		cardLogs := grouped[cardID]
+		total += cardLogs[len(cardLogs)-1].Stability
+		reviews += len(cardLogs)
+	}
+	if reviews == 0 {
+		return 0
+	}
+	return total / float64(reviews)
+}
+
+// MedianHalfLife returns the median HalfLife of the reviewed cards, or 0
+// when every card is New.
+func (s *Scheduler) MedianHalfLife(cards []Card) time.Duration {
+	var halfLives []time.Duration
+	for _, card := range cards {
+		if card.State != New && card.Stability > 0 {
+			halfLives = append(halfLives, s.HalfLife(card.Stability))
+		}
+	}
+	if len(halfLives) == 0 {
+		return 0
+	}
+	slices.Sort(halfLives)
+	mid := len(halfLives) / 2
+	if len(halfLives)%2 == 1 {
+		return halfLives[mid]
+	}
+	return (halfLives[mid-1] + halfLives[mid]) / 2
+}
+
+// TransitionMatrix counts, over consecutive reviews of the same card, how
+// often rating i was followed by rating j, indexed from Again at 0. Logs
+// with invalid ratings break the chain.
+func TransitionMatrix(logs []ReviewLog) [4][4]int {
+	learning, review := TransitionMatrixByState(logs)
+	var matrix [4][4]int
+	for i := range matrix {
+		for j := range matrix[i] {
+			matrix[i][j] = learning[i][j] + review[i][j]
+		}
+	}
+	return matrix
+}
+
+// TransitionMatrixByState splits TransitionMatrix by the state of the
+// second review: Review, or any other state for learning.
+func TransitionMatrixByState(logs []ReviewLog) (learning, review [4][4]int) {
+	cardIDs, grouped := logsByCard(logs)
+	for _, cardID := range cardIDs {
+		cardLogs := grouped[cardID]
+		for k := 1; k < len(cardLogs); k++ {
+			prev, next := cardLogs[k-1], cardLogs[k]
+			if prev.Rating < Again || prev.Rating > Easy || next.Rating < Again || next.Rating > Easy {
+				continue
+			}
+			if next.State == Review {
+				review[prev.Rating-Again][next.Rating-Again]++
+			} else {
+				learning[prev.Rating-Again][next.Rating-Again]++
+			}
+		}
+	}
+	return learning, review
+}
+
+// TransitionProbabilities normalizes each row of TransitionMatrix, giving
+// the probability of each rating following rating i. Rows without
+// transitions are zero.
+func TransitionProbabilities(logs []ReviewLog) [4][4]float64 {
+	counts := TransitionMatrix(logs)
+	var probabilities [4][4]float64
+	for i, row := range counts {
+		total := 0
+		for _, count := range row {
+			total += count
+		}
+		if total == 0 {
+			continue
+		}
+		for j, count := range row {
+			probabilities[i][j] = float64(count) / float64(total)
+		}
+	}
+	return probabilities
+}
+
+const (
+	minFuzzFactorLogs = 10
+	maxFuzzFactor     = 3.0
+)
+
+// SuggestFuzzFactor returns a scaling for the default fuzz bands that
+// matches how far from schedule the reviews in logs actually happened: the
+// median distance between elapsed and scheduled time, measured in fuzz
+// band half-widths, clamped to [1, 3]. Only Review-state reviews with
+// intervals long enough to be fuzzed count, and fewer than 10 of them
+// return 1.
+func SuggestFuzzFactor(logs []ReviewLog) float64 {
+	var deviations []float64
+	for _, log := range logs {
+		if log.Kind != LogReview || log.State != Review {
+			continue
+		}
+		scheduledDays := log.ScheduledInterval.Hours() / dayDuration.Hours()
+		delta := fuzzDelta(scheduledDays)
+		if delta <= 0 {
+			continue
+		}
+		elapsedDays := log.Elapsed.Hours() / dayDuration.Hours()
+		deviations = append(deviations, math.Abs(elapsedDays-scheduledDays)/delta)
+	}
+	if len(deviations) < minFuzzFactorLogs {
+		return 1.0
+	}
+
+	slices.Sort(deviations)
+	mid := len(deviations) / 2
+	median := deviations[mid]
+	if len(deviations)%2 == 0 {
+		median = (deviations[mid-1] + deviations[mid]) / 2
+	}
+	return math.Min(math.Max(median, 1.0), maxFuzzFactor)
+}
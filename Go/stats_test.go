@@ -0,0 +1,231 @@
+package fsrs
+
+import (
+	"math"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestRatingEntropy(t *testing.T) {
+	var allGood []ReviewLog
+	for i := range 20 {
+		allGood = append(allGood, ReviewLog{CardID: int64(i), Rating: Good})
+	}
+	if entropy := RatingEntropy(allGood); entropy != 0 {
+		t.Errorf("Expected entropy 0 for all-Good logs, but got %v", entropy)
+	}
+
+	var even []ReviewLog
+	for i := range 20 {
+		even = append(even, ReviewLog{CardID: int64(i), Rating: Ratings()[i%4]})
+	}
+	if entropy := RatingEntropy(even); math.Abs(entropy-2.0) > 1e-9 {
+		t.Errorf("Expected entropy 2 for an even split, but got %v", entropy)
+	}
+
+	if entropy := RatingEntropy(nil); entropy != 0 {
+		t.Errorf("Expected entropy 0 for no logs, but got %v", entropy)
+	}
+}
+
+func TestGroupLogsPreservesMetaAndOrder(t *testing.T) {
+	start := time.Date(2025, 1, 1, 9, 0, 0, 0, time.UTC)
+	logs := []ReviewLog{
+		{CardID: 1, Rating: Good, ReviewTime: start, Meta: map[string]string{"tag": "verbs", "deck": "es"}},
+		{CardID: 2, Rating: Again, ReviewTime: start.Add(time.Minute), Meta: map[string]string{"tag": "nouns"}},
+		{CardID: 3, Rating: Hard, ReviewTime: start.Add(2 * time.Minute), Meta: map[string]string{"tag": "verbs"}},
+		{CardID: 4, Rating: Easy, ReviewTime: start.Add(3 * time.Minute)},
+	}
+
+	groups := GroupLogs(logs, func(log ReviewLog) string { return log.Meta["tag"] })
+
+	expected := map[string][]ReviewLog{
+		"verbs": {logs[0], logs[2]},
+		"nouns": {logs[1]},
+		"":      {logs[3]},
+	}
+	if !reflect.DeepEqual(expected, groups) {
+		t.Errorf("Expected %v, but got %v", expected, groups)
+	}
+}
+
+func TestBundlePreservesMeta(t *testing.T) {
+	logs := []ReviewLog{
+		{CardID: 1, Rating: Good, ReviewTime: time.Date(2025, 1, 1, 9, 0, 0, 0, time.UTC), Meta: map[string]string{"tag": "verbs", "source": "lesson 3"}},
+		{CardID: 1, Rating: Again, ReviewTime: time.Date(2025, 1, 2, 9, 0, 0, 0, time.UTC)},
+	}
+
+	data, err := MarshalBundle(nil, logs)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	_, restored, err := UnmarshalBundle(data)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(logs, restored) {
+		t.Errorf("Expected %v, but got %v", logs, restored)
+	}
+}
+
+func TestReviewTimeHistogram(t *testing.T) {
+	logs := []ReviewLog{
+		{CardID: 1, Rating: Good, ReviewTime: time.Date(2025, 1, 1, 9, 15, 0, 0, time.UTC)},
+		{CardID: 2, Rating: Good, ReviewTime: time.Date(2025, 1, 1, 9, 45, 0, 0, time.UTC)},
+		{CardID: 3, Rating: Again, ReviewTime: time.Date(2025, 1, 1, 21, 0, 0, 0, time.UTC)},
+		{CardID: 4, Rating: Good, ReviewTime: time.Date(2025, 1, 2, 2, 30, 0, 0, time.UTC)},
+	}
+
+	utc := ReviewTimeHistogram(logs, time.UTC)
+	var expected [24]int
+	expected[9] = 2
+	expected[21] = 1
+	expected[2] = 1
+	if utc != expected {
+		t.Errorf("Expected %v, but got %v", expected, utc)
+	}
+
+	newYork := time.FixedZone("EST", -5*60*60)
+	local := ReviewTimeHistogram(logs, newYork)
+	expected = [24]int{}
+	expected[4] = 2
+	expected[16] = 1
+	expected[21] = 1
+	if local != expected {
+		t.Errorf("Expected %v, but got %v", expected, local)
+	}
+}
+
+func TestSessionProgress(t *testing.T) {
+	scheduler := createDefaultScheduler()
+	sessionStart := time.Date(2025, 3, 1, 18, 0, 0, 0, time.UTC)
+	logs := []ReviewLog{
+		{CardID: 1, Rating: Good, ReviewTime: sessionStart.Add(-time.Hour)},
+		{CardID: 2, Rating: Again, ReviewTime: sessionStart.Add(time.Minute)},
+		{CardID: 3, Rating: Good, ReviewTime: sessionStart.Add(2 * time.Minute)},
+		{CardID: 2, Rating: Good, ReviewTime: sessionStart.Add(12 * time.Minute)},
+		{CardID: 4, Rating: Easy, ReviewTime: sessionStart.Add(13 * time.Minute)},
+	}
+
+	done, remaining := scheduler.SessionProgress(10, logs, sessionStart)
+	if done != 3 || remaining != 7 {
+		t.Errorf("Expected 3 done and 7 remaining, but got %d and %d", done, remaining)
+	}
+
+	done, remaining = scheduler.SessionProgress(2, logs, sessionStart)
+	if done != 3 || remaining != 0 {
+		t.Errorf("Expected 3 done and 0 remaining, but got %d and %d", done, remaining)
+	}
+}
+
+func TestReviewEfficiency(t *testing.T) {
+	config := DefaultSchedulerConfig()
+	config.EnableFuzzing = false
+	scheduler, _ := NewScheduler(config, testRand)
+	start := time.Date(2025, 1, 1, 9, 0, 0, 0, time.UTC)
+
+	history := func(cardID int64, ratings []Rating) []ReviewLog {
+		card := NewCard(cardID)
+		now := start
+		var logs []ReviewLog
+		for _, rating := range ratings {
+			result, _ := scheduler.ReviewAt(card, rating, now)
+			logs = append(logs, result.Log)
+			card = result.Card
+			now = now.Add(card.Interval)
+		}
+		return logs
+	}
+
+	onTime := ReviewEfficiency(history(1, []Rating{Good, Good, Good, Good, Good, Good}))
+	lapsing := ReviewEfficiency(history(2, []Rating{Good, Good, Good, Again, Good, Again}))
+	if onTime <= lapsing {
+		t.Errorf("Expected on-time Good reviews to be more efficient, but got %v vs %v", onTime, lapsing)
+	}
+	if ReviewEfficiency(nil) != 0 {
+		t.Errorf("Expected zero efficiency without logs")
+	}
+}
+
+func TestMedianHalfLife(t *testing.T) {
+	scheduler := createDefaultScheduler()
+	cards := []Card{
+		{CardID: 1, State: Review, Stability: 2, Difficulty: 5},
+		{CardID: 2, State: Review, Stability: 30, Difficulty: 5},
+		{CardID: 3, State: Review, Stability: 8, Difficulty: 5},
+		NewCard(4),
+	}
+	if got, expected := scheduler.MedianHalfLife(cards), scheduler.HalfLife(8); got != expected {
+		t.Errorf("Expected %v, but got %v", expected, got)
+	}
+	if got := scheduler.MedianHalfLife([]Card{NewCard(5)}); got != 0 {
+		t.Errorf("Expected 0, but got %v", got)
+	}
+}
+
+func TestTransitionMatrix(t *testing.T) {
+	start := time.Date(2025, 1, 1, 9, 0, 0, 0, time.UTC)
+	at := func(days int) time.Time { return start.AddDate(0, 0, days) }
+	logs := []ReviewLog{
+		{CardID: 1, Rating: Hard, State: Review, ReviewTime: at(3)},
+		{CardID: 1, Rating: Good, State: New, ReviewTime: at(0)},
+		{CardID: 1, Rating: Again, State: Review, ReviewTime: at(6)},
+		{CardID: 2, Rating: Hard, State: Review, ReviewTime: at(1)},
+		{CardID: 2, Rating: Again, State: Review, ReviewTime: at(4)},
+		{CardID: 2, Rating: Good, State: Relearning, ReviewTime: at(4).Add(10 * time.Minute)},
+		{CardID: 3, Rating: Easy, State: New, ReviewTime: at(0)},
+	}
+
+	var expected [4][4]int
+	expected[Good-Again][Hard-Again] = 1
+	expected[Hard-Again][Again-Again] = 2
+	expected[Again-Again][Good-Again] = 1
+	if got := TransitionMatrix(logs); got != expected {
+		t.Errorf("Expected %v, but got %v", expected, got)
+	}
+
+	learning, review := TransitionMatrixByState(logs)
+	if learning[Again-Again][Good-Again] != 1 || review[Hard-Again][Again-Again] != 2 || review[Again-Again][Good-Again] != 0 {
+		t.Errorf("Expected the relearning transition apart from review ones, but got %v and %v", learning, review)
+	}
+
+	probabilities := TransitionProbabilities(logs)
+	if probabilities[Hard-Again][Again-Again] != 1 || probabilities[Easy-Again] != [4]float64{} {
+		t.Errorf("Expected row-normalized probabilities, but got %v", probabilities)
+	}
+}
+
+func TestSuggestFuzzFactor(t *testing.T) {
+	build := func(offsets []time.Duration) []ReviewLog {
+		var logs []ReviewLog
+		for i, offset := range offsets {
+			logs = append(logs, ReviewLog{
+				CardID:            int64(i),
+				Rating:            Good,
+				State:             Review,
+				ScheduledInterval: 20 * dayDuration,
+				Elapsed:           20*dayDuration + offset,
+			})
+		}
+		return logs
+	}
+
+	var consistent, erratic []time.Duration
+	for i := range 12 {
+		consistent = append(consistent, time.Duration(i%3-1)*time.Hour)
+		erratic = append(erratic, time.Duration(3*(2*(i%2)-1))*dayDuration)
+	}
+
+	consistentFactor := SuggestFuzzFactor(build(consistent))
+	if consistentFactor != 1.0 {
+		t.Errorf("Expected 1 for consistent timing, but got %v", consistentFactor)
+	}
+	erraticFactor := SuggestFuzzFactor(build(erratic))
+	if erraticFactor <= consistentFactor || erraticFactor > maxFuzzFactor {
+		t.Errorf("Expected a factor in (%v, %v] for erratic timing, but got %v", consistentFactor, maxFuzzFactor, erraticFactor)
+	}
+	if got := SuggestFuzzFactor(build(erratic[:5])); got != 1.0 {
+		t.Errorf("Expected 1 for insufficient data, but got %v", got)
+	}
+}
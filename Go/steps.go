@@ -0,0 +1,104 @@
+package fsrs
+
+import (
+	"math"
+	"slices"
+	"time"
+)
+
+// EvaluateLearningSteps returns the next-day pass rate of historical
+// learning sequences, weighted by how closely their sub-day gaps match steps.
+func (s *Scheduler) EvaluateLearningSteps(steps []time.Duration, logs []ReviewLog) float64 {
+	cardIDs, grouped := logsByCard(logs)
+
+	totalWeight := 0.0
+	weightedPasses := 0.0
+	for _, cardID := range cardIDs {
+		gaps, passed, ok := learningSequence(grouped[cardID])
+		if !ok {
+			continue
+		}
+		weight := math.Exp(-stepsDistance(steps, gaps))
+		totalWeight += weight
+		if passed {
+			weightedPasses += weight
+		}
+	}
+
+	if totalWeight == 0 {
+		return 0
+	}
+	return weightedPasses / totalWeight
+}
+
+func learningSequence(cardLogs []ReviewLog) (gaps []time.Duration, passed bool, ok bool) {
+	for i := 1; i < len(cardLogs); i++ {
+		gap := cardLogs[i].ReviewTime.Sub(cardLogs[i-1].ReviewTime)
+		if gap >= dayDuration {
+			return gaps, cardLogs[i].Rating != Again, true
+		}
+		gaps = append(gaps, gap)
+	}
+	return nil, false, false
+}
+
+func stepsDistance(steps, gaps []time.Duration) float64 {
+	distance := 0.0
+	for i := range max(len(steps), len(gaps)) {
+		if i >= len(steps) || i >= len(gaps) {
+			distance += 2.0
+			continue
+		}
+		a := math.Max(steps[i].Minutes(), 1.0/60.0)
+		b := math.Max(gaps[i].Minutes(), 1.0/60.0)
+		distance += math.Abs(math.Log(a / b))
+	}
+	return distance
+}
+
+const (
+	defaultFirstStep      = time.Minute
+	minFirstStepSamples   = 20
+	firstStepLowPassRate  = 0.8
+	firstStepHighPassRate = 0.95
+)
+
+// SuggestFirstStep recommends a first learning step from the gap between
+// each card's first and second review, when that gap is under a day. The
+// median gap is halved when under 80% of those reviews pass, doubled when
+// over 95% pass, and kept otherwise. With fewer than 20 such cards it
+// returns one minute.
+func SuggestFirstStep(logs []ReviewLog) time.Duration {
+	cardIDs, grouped := logsByCard(logs)
+
+	var gaps []time.Duration
+	passes := 0
+	for _, cardID := range cardIDs {
+		cardLogs := grouped[cardID]
+		if len(cardLogs) < 2 {
+			continue
+		}
+		gap := cardLogs[1].ReviewTime.Sub(cardLogs[0].ReviewTime)
+		if gap >= dayDuration {
+			continue
+		}
+		gaps = append(gaps, gap)
+		if cardLogs[1].Rating != Again {
+			passes++
+		}
+	}
+	if len(gaps) < minFirstStepSamples {
+		return defaultFirstStep
+	}
+
+	slices.Sort(gaps)
+	median := gaps[len(gaps)/2]
+	passRate := float64(passes) / float64(len(gaps))
+	switch {
+	case passRate < firstStepLowPassRate:
+		median /= 2
+	case passRate > firstStepHighPassRate:
+		median *= 2
+	}
+	return median.Round(time.Second)
+}
@@ -0,0 +1,79 @@
+package fsrs
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEvaluateLearningSteps(t *testing.T) {
+	scheduler := createDefaultScheduler()
+	start := time.Date(2025, 1, 1, 9, 0, 0, 0, time.UTC)
+
+	var logs []ReviewLog
+	cardID := int64(0)
+	addSequence := func(gaps []time.Duration, passed bool) {
+		cardID++
+		reviewTime := start
+		logs = append(logs, ReviewLog{CardID: cardID, Rating: Again, ReviewTime: reviewTime})
+		for _, gap := range gaps {
+			reviewTime = reviewTime.Add(gap)
+			logs = append(logs, ReviewLog{CardID: cardID, Rating: Good, ReviewTime: reviewTime})
+		}
+		rating := Good
+		if !passed {
+			rating = Again
+		}
+		logs = append(logs, ReviewLog{CardID: cardID, Rating: rating, ReviewTime: reviewTime.Add(dayDuration)})
+	}
+
+	for i := range 20 {
+		addSequence([]time.Duration{time.Minute, 10 * time.Minute}, i%10 != 0)
+		addSequence([]time.Duration{time.Minute}, i%2 == 0)
+	}
+
+	wellSpaced := scheduler.EvaluateLearningSteps([]time.Duration{time.Minute, 10 * time.Minute}, logs)
+	tiny := scheduler.EvaluateLearningSteps([]time.Duration{time.Minute}, logs)
+
+	if wellSpaced <= tiny {
+		t.Errorf("Expected two-step config to score higher, but got %v vs %v", wellSpaced, tiny)
+	}
+	if wellSpaced < 0 || wellSpaced > 1 || tiny < 0 || tiny > 1 {
+		t.Errorf("Expected scores in [0, 1], but got %v and %v", wellSpaced, tiny)
+	}
+	if score := scheduler.EvaluateLearningSteps([]time.Duration{time.Minute}, nil); score != 0 {
+		t.Errorf("Expected 0 without history, but got %v", score)
+	}
+}
+
+func TestSuggestFirstStep(t *testing.T) {
+	start := time.Date(2025, 1, 1, 9, 0, 0, 0, time.UTC)
+	firstSteps := func(gap time.Duration, passed func(i int) bool) []ReviewLog {
+		var logs []ReviewLog
+		for i := range 40 {
+			cardID := int64(i + 1)
+			rating := Good
+			if !passed(i) {
+				rating = Again
+			}
+			logs = append(logs,
+				ReviewLog{CardID: cardID, Rating: Good, ReviewTime: start},
+				ReviewLog{CardID: cardID, Rating: rating, ReviewTime: start.Add(gap)},
+			)
+		}
+		return logs
+	}
+
+	lapsing := firstSteps(5*time.Minute, func(i int) bool { return i%2 == 0 })
+	if got := SuggestFirstStep(lapsing); got >= 5*time.Minute {
+		t.Errorf("Expected frequent early lapses to shorten the step, but got %v", got)
+	}
+
+	passing := firstSteps(5*time.Minute, func(int) bool { return true })
+	if got := SuggestFirstStep(passing); got <= 5*time.Minute {
+		t.Errorf("Expected always-passed steps to lengthen, but got %v", got)
+	}
+
+	if got := SuggestFirstStep(lapsing[:10]); got != time.Minute {
+		t.Errorf("Expected the one minute default for thin data, but got %v", got)
+	}
+}
@@ -0,0 +1,70 @@
+package fsrs
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+)
+
+var ErrUnknownCard = errors.New("unknown card")
+
+type TimedReview struct {
+	CardID     int64
+	Rating     Rating
+	ReviewTime time.Time
+}
+
+// StreamError reports a review from ApplyReviewStream that was not applied.
+type StreamError struct {
+	Review TimedReview
+	Err    error
+}
+
+func (e *StreamError) Error() string {
+	return fmt.Sprintf("card %d at %v: %v", e.Review.CardID, e.Review.ReviewTime, e.Err)
+}
+
+func (e *StreamError) Unwrap() error {
+	return e.Err
+}
+
+// ApplyReviewStream applies interleaved reviews of many cards in time order,
+// taking each card's elapsed time from its previous review via ReviewAt.
+// Reviews at the same time are applied in CardID order, then input order,
+// so with fuzzing the result does not depend on how the stream was
+// interleaved. Reviews that ReviewAt adjusted for clock skew have a
+// non-zero SkewAdjustment in their log.
+// A review that fails is reported as a *StreamError and skipped; the
+// remaining reviews of the same card still apply. The input map is not
+// modified.
+func (s *Scheduler) ApplyReviewStream(cards map[int64]Card, reviews []TimedReview) (map[int64]Card, []ReviewLog, []error) {
+	ordered := slices.Clone(reviews)
+	slices.SortStableFunc(ordered, func(a, b TimedReview) int {
+		return cmp.Or(a.ReviewTime.Compare(b.ReviewTime), cmp.Compare(a.CardID, b.CardID))
+	})
+
+	updated := maps.Clone(cards)
+	if updated == nil {
+		updated = make(map[int64]Card)
+	}
+	logs := make([]ReviewLog, 0, len(ordered))
+	var errs []error
+	for _, review := range ordered {
+		card, ok := updated[review.CardID]
+		if !ok {
+			errs = append(errs, &StreamError{Review: review, Err: ErrUnknownCard})
+			continue
+		}
+		result, err := s.ReviewAt(card, review.Rating, review.ReviewTime)
+		if err != nil {
+			errs = append(errs, &StreamError{Review: review, Err: err})
+			continue
+		}
+		updated[review.CardID] = result.Card
+		logs = append(logs, result.Log)
+	}
+	return updated, logs, errs
+}
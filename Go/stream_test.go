@@ -0,0 +1,104 @@
+package fsrs
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+	"time"
+)
+
+func TestApplyReviewStream(t *testing.T) {
+	config := DefaultSchedulerConfig()
+	config.EnableFuzzing = false
+	scheduler, _ := NewScheduler(config, testRand)
+	start := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+	cards := map[int64]Card{1: NewCard(1), 2: NewCard(2)}
+
+	reviews := []TimedReview{
+		{CardID: 2, Rating: Good, ReviewTime: start.Add(2 * time.Minute)},
+		{CardID: 1, Rating: Good, ReviewTime: start.Add(20 * time.Minute)},
+		{CardID: 9, Rating: Good, ReviewTime: start.Add(3 * time.Minute)},
+		{CardID: 1, Rating: Again, ReviewTime: start},
+		{CardID: 1, Rating: Good, ReviewTime: start.Add(5 * time.Minute)},
+	}
+	updated, logs, errs := scheduler.ApplyReviewStream(cards, reviews)
+
+	expected := NewCard(1)
+	expected = scheduler.ReviewCard(expected, Again, 0)
+	expected = scheduler.ReviewCard(expected, Good, 5*time.Minute)
+	expected = scheduler.ReviewCard(expected, Good, 15*time.Minute)
+	expected.LastReview = start.Add(20 * time.Minute)
+	if updated[1] != expected {
+		t.Errorf("Expected %+v, but got %+v", expected, updated[1])
+	}
+	if updated[2].State != Learning || !updated[2].LastReview.Equal(start.Add(2*time.Minute)) {
+		t.Errorf("Expected card 2 to be reviewed once, but got %+v", updated[2])
+	}
+	if cards[1] != NewCard(1) {
+		t.Errorf("Expected the input map to be left unchanged")
+	}
+
+	if len(logs) != 4 {
+		t.Fatalf("Expected 4 logs, but got %d", len(logs))
+	}
+	for i := 1; i < len(logs); i++ {
+		if logs[i].ReviewTime.Before(logs[i-1].ReviewTime) {
+			t.Errorf("Expected logs in time order, but got %v before %v", logs[i-1].ReviewTime, logs[i].ReviewTime)
+		}
+	}
+
+	var streamErr *StreamError
+	if len(errs) != 1 || !errors.Is(errs[0], ErrUnknownCard) || !errors.As(errs[0], &streamErr) || streamErr.Review.CardID != 9 {
+		t.Errorf("Expected one unknown card error for card 9, but got %v", errs)
+	}
+}
+
+func TestApplyReviewStreamReportsSkew(t *testing.T) {
+	config := DefaultSchedulerConfig()
+	config.EnableFuzzing = false
+	config.ClockSkewTolerance = time.Minute
+	scheduler, _ := NewScheduler(config, testRand)
+	start := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+	reviewed, _ := scheduler.ReviewAt(NewCard(1), Good, start.Add(30*time.Second))
+	cards := map[int64]Card{1: reviewed.Card, 2: NewCard(2)}
+
+	_, logs, errs := scheduler.ApplyReviewStream(cards, []TimedReview{
+		{CardID: 1, Rating: Good, ReviewTime: start},
+		{CardID: 2, Rating: Good, ReviewTime: start},
+	})
+	if len(errs) != 0 || len(logs) != 2 {
+		t.Fatalf("Expected 2 logs without errors, but got %d logs and %v", len(logs), errs)
+	}
+	if logs[0].CardID != 1 || logs[0].SkewAdjustment != 30*time.Second {
+		t.Errorf("Expected card 1 to be adjusted by 30s, but got %+v", logs[0])
+	}
+	if logs[1].SkewAdjustment != 0 {
+		t.Errorf("Expected card 2 not to be adjusted, but got %v", logs[1].SkewAdjustment)
+	}
+}
+
+func BenchmarkApplyReviewStream(b *testing.B) {
+	config := DefaultSchedulerConfig()
+	config.EnableFuzzing = false
+	scheduler, _ := NewScheduler(config, testRand)
+	random := rand.New(rand.NewSource(7))
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	cards := make(map[int64]Card, 10000)
+	for id := int64(0); id < 10000; id++ {
+		cards[id] = NewCard(id)
+	}
+	reviews := make([]TimedReview, 100000)
+	for i := range reviews {
+		reviews[i] = TimedReview{
+			CardID:     random.Int63n(10000),
+			Rating:     Rating(random.Intn(4) + 1),
+			ReviewTime: start.Add(time.Duration(random.Int63n(int64(365 * dayDuration)))),
+		}
+	}
+
+	b.ResetTimer()
+	for range b.N {
+		scheduler.ApplyReviewStream(cards, reviews)
+	}
+}
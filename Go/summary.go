@@ -0,0 +1,55 @@
+package fsrs
+
+import "time"
+
+const summaryMatureStability = 100.0
+
+// SchedulerSummary describes a scheduler for display. FirstGoodInterval
+// follows a single Good on a new card, GraduatingInterval the first Review
+// interval when every learning step is passed with Good, and
+// MatureInterval the interval at MatureStability days of stability.
+// None of them include fuzz.
+type SchedulerSummary struct {
+	DesiredRetention     float64
+	Decay                float64
+	TypicalGrowthFactor  float64
+	FirstGoodInterval    time.Duration
+	GraduatingInterval   time.Duration
+	MatureStability      float64
+	MatureInterval       time.Duration
+	EffectiveMaxInterval time.Duration
+}
+
+func (s *Scheduler) Summary() SchedulerSummary {
+	decay, _ := s.Decay()
+	first := s.previewCard(NewCard(0), Good, 0)
+
+	return SchedulerSummary{
+		DesiredRetention:     s.config.DesiredRetention,
+		Decay:                decay,
+		TypicalGrowthFactor:  s.TypicalGrowthFactor(),
+		FirstGoodInterval:    first.Interval,
+		GraduatingInterval:   s.graduate(first).Interval,
+		MatureStability:      summaryMatureStability,
+		MatureInterval:       s.intervalForRetention(summaryMatureStability, s.desiredRetention(5)),
+		EffectiveMaxInterval: time.Duration(s.config.MaximumInterval) * dayDuration,
+	}
+}
+
+// InitialIntervalsTable returns, for each first rating of a New card, the
+// first Review interval it gets when every later learning step is rated
+// Good. Fuzz is not applied.
+func (s *Scheduler) InitialIntervalsTable() map[Rating]time.Duration {
+	table := make(map[Rating]time.Duration, 4)
+	for _, rating := range Ratings() {
+		table[rating] = s.graduate(s.previewCard(NewCard(0), rating, 0)).Interval
+	}
+	return table
+}
+
+func (s *Scheduler) graduate(card Card) Card {
+	for card.State != Review {
+		card = s.previewCard(card, Good, card.Interval)
+	}
+	return card
+}
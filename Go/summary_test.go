@@ -0,0 +1,56 @@
+package fsrs
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSummary(t *testing.T) {
+	scheduler := createDefaultScheduler()
+	summary := scheduler.Summary()
+
+	if summary.DesiredRetention != 0.9 || summary.Decay != DefaultSchedulerConfig().Parameters[20] {
+		t.Errorf("Expected the configured retention and decay, but got %+v", summary)
+	}
+	if summary.TypicalGrowthFactor <= 1 {
+		t.Errorf("Expected a growth factor above 1, but got %v", summary.TypicalGrowthFactor)
+	}
+	if summary.FirstGoodInterval != 10*time.Minute {
+		t.Errorf("Expected the second learning step, but got %v", summary.FirstGoodInterval)
+	}
+	if !(summary.FirstGoodInterval < summary.GraduatingInterval && summary.GraduatingInterval < summary.MatureInterval && summary.MatureInterval <= summary.EffectiveMaxInterval) {
+		t.Errorf("Expected increasing intervals, but got %+v", summary)
+	}
+	if summary.MatureInterval != scheduler.CalculateNextReviewInterval(summary.MatureStability) {
+		t.Errorf("Expected the mature interval to match CalculateNextReviewInterval, but got %v", summary.MatureInterval)
+	}
+
+	config := DefaultSchedulerConfig()
+	config.LearningSteps = nil
+	config.MaximumInterval = 30
+	capped, _ := NewScheduler(config, testRand)
+	summary = capped.Summary()
+	if summary.FirstGoodInterval != summary.GraduatingInterval || summary.MatureInterval != summary.EffectiveMaxInterval {
+		t.Errorf("Expected immediate graduation and a capped mature interval, but got %+v", summary)
+	}
+}
+
+func TestInitialIntervalsTable(t *testing.T) {
+	scheduler := createDefaultScheduler()
+	table := scheduler.InitialIntervalsTable()
+
+	if len(table) != 4 {
+		t.Fatalf("Expected an entry per rating, but got %v", table)
+	}
+	for rating, interval := range table {
+		if interval < dayDuration {
+			t.Errorf("Expected at least one day for rating %v, but got %v", rating, interval)
+		}
+	}
+	if table[Easy] < table[Good] || table[Good] < table[Again] {
+		t.Errorf("Expected intervals to grow with the first rating, but got %v", table)
+	}
+	if table[Good] != scheduler.Summary().GraduatingInterval {
+		t.Errorf("Expected the Good entry to match the summary's graduating interval")
+	}
+}
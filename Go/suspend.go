@@ -0,0 +1,56 @@
+package fsrs
+
+// SuspendThresholds configure ShouldSuspendWith. A card is a candidate once its
+// last RecentReviews reviews in the Review state average a retrievability
+// at review of at most MaxRetrievability and at least MinLapseRate of them
+// are lapses.
+type SuspendThresholds struct {
+	RecentReviews     int
+	MaxRetrievability float64
+	MinLapseRate      float64
+}
+
+func DefaultSuspendThresholds() SuspendThresholds {
+	return SuspendThresholds{
+		RecentReviews:     8,
+		MaxRetrievability: 0.8,
+		MinLapseRate:      0.4,
+	}
+}
+
+// ShouldSuspend checks the card against DefaultSuspendThresholds.
+func (s *Scheduler) ShouldSuspend(card Card, logs []ReviewLog) bool {
+	return s.ShouldSuspendWith(card, logs, DefaultSuspendThresholds())
+}
+
+// ShouldSuspendWith reports whether the card keeps failing despite
+// reviews, judged from its logs in logs against thresholds. Retrievability
+// at each review comes from the stability recorded in the log before it
+// and the elapsed time.
+func (s *Scheduler) ShouldSuspendWith(card Card, logs []ReviewLog, thresholds SuspendThresholds) bool {
+	_, grouped := logsByCard(logs)
+	cardLogs := grouped[card.CardID]
+
+	var retrievabilities []float64
+	lapses := 0
+	for i := len(cardLogs) - 1; i > 0 && len(retrievabilities) < thresholds.RecentReviews; i-- {
+		if cardLogs[i].State != Review {
+			continue
+		}
+		retrievabilities = append(retrievabilities, s.retrievability(cardLogs[i-1].Stability, cardLogs[i].Elapsed))
+		if cardLogs[i].Rating == Again {
+			lapses++
+		}
+	}
+	if len(retrievabilities) < thresholds.RecentReviews {
+		return false
+	}
+
+	total := 0.0
+	for _, r := range retrievabilities {
+		total += r
+	}
+	meanRetrievability := total / float64(len(retrievabilities))
+	lapseRate := float64(lapses) / float64(len(retrievabilities))
+	return meanRetrievability <= thresholds.MaxRetrievability && lapseRate >= thresholds.MinLapseRate
+}
@@ -0,0 +1,61 @@
+package fsrs
+
+import (
+	"testing"
+	"time"
+)
+
+func TestShouldSuspend(t *testing.T) {
+	config := DefaultSchedulerConfig()
+	config.EnableFuzzing = false
+	scheduler, _ := NewScheduler(config, testRand)
+	start := time.Date(2025, 1, 1, 9, 0, 0, 0, time.UTC)
+
+	history := func(cardID int64, lateness float64, rating func(Card) Rating, reviews int) (Card, []ReviewLog) {
+		card := NewCard(cardID)
+		now := start
+		var logs []ReviewLog
+		for range reviews {
+			result, _ := scheduler.ReviewAt(card, rating(card), now)
+			logs = append(logs, result.Log)
+			card = result.Card
+			wait := card.Interval
+			if card.State == Review {
+				wait = time.Duration(float64(wait) * lateness)
+			}
+			now = now.Add(wait)
+		}
+		return card, logs
+	}
+
+	chronic, chronicLogs := history(1, 4, func(card Card) Rating {
+		if card.State == Review {
+			return Again
+		}
+		return Good
+	}, 40)
+	normal, normalLogs := history(2, 1, func(Card) Rating { return Good }, 12)
+	logs := append(chronicLogs, normalLogs...)
+
+	if !scheduler.ShouldSuspend(chronic, logs) {
+		t.Errorf("Expected a chronically failed card to be suspended")
+	}
+	if scheduler.ShouldSuspend(normal, logs) {
+		t.Errorf("Expected a normal card not to be suspended")
+	}
+
+	strict := DefaultSuspendThresholds()
+	strict.MinLapseRate = 1.5
+	if scheduler.ShouldSuspendWith(chronic, logs, strict) {
+		t.Errorf("Expected the given thresholds to be used")
+	}
+	lenient := DefaultSuspendThresholds()
+	lenient.MaxRetrievability = 1
+	lenient.MinLapseRate = 0
+	if !scheduler.ShouldSuspendWith(normal, logs, lenient) {
+		t.Errorf("Expected a zero lapse rate threshold to be honored")
+	}
+	if scheduler.ShouldSuspend(chronic, chronicLogs[:5]) {
+		t.Errorf("Expected too few reviews not to suspend")
+	}
+}
@@ -0,0 +1,62 @@
+package fsrs
+
+import (
+	"errors"
+	"fmt"
+)
+
+var ErrSyncConflict = errors.New("card versions diverged")
+
+// Conflicts reports whether two versions of the same card have diverged:
+// they differ but were last reviewed at the same time, or the version
+// reviewed later has not had more reviews than the other, so it cannot
+// have been derived from it. Cards with different IDs never conflict.
+func Conflicts(a, b Card) bool {
+	if a.CardID != b.CardID || sameCard(a, b) {
+		return false
+	}
+	if a.LastReview.Equal(b.LastReview) {
+		return true
+	}
+	older, newer := a, b
+	if newer.LastReview.Before(older.LastReview) {
+		older, newer = newer, older
+	}
+	return newer.Reps <= older.Reps
+}
+
+// MergeCards reconciles two versions of the same card by fast-forwarding
+// to the one reviewed later. When they conflict it returns that version
+// together with ErrSyncConflict, leaving the resolution to the caller.
+func MergeCards(a, b Card) (Card, error) {
+	if a.CardID != b.CardID {
+		return Card{}, fmt.Errorf("cannot merge card %d with card %d", a.CardID, b.CardID)
+	}
+	newer := a
+	if b.LastReview.After(a.LastReview) {
+		newer = b
+	}
+	if Conflicts(a, b) {
+		return newer, fmt.Errorf("%w: card %d", ErrSyncConflict, a.CardID)
+	}
+	return newer, nil
+}
+
+// sameCard compares every field of two cards, with LastReview compared as
+// an instant so that copies which went through serialization or another
+// time zone still match.
+func sameCard(a, b Card) bool {
+	return a.CardID == b.CardID &&
+		a.Interval == b.Interval &&
+		a.Stability == b.Stability &&
+		a.Difficulty == b.Difficulty &&
+		a.State == b.State &&
+		a.Step == b.Step &&
+		a.LastReview.Equal(b.LastReview) &&
+		a.ConsecutiveHard == b.ConsecutiveHard &&
+		a.Reps == b.Reps &&
+		a.ConsecutiveDefers == b.ConsecutiveDefers &&
+		a.DeferredBy == b.DeferredBy &&
+		a.SameDayUpdates == b.SameDayUpdates &&
+		a.ParamsID == b.ParamsID
+}
@@ -0,0 +1,78 @@
+package fsrs
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestConflicts(t *testing.T) {
+	config := DefaultSchedulerConfig()
+	config.EnableFuzzing = false
+	scheduler, _ := NewScheduler(config, testRand)
+	start := time.Date(2025, 5, 1, 9, 0, 0, 0, time.UTC)
+
+	base, _ := scheduler.ReviewAt(NewCard(1), Good, start)
+	synced, _ := scheduler.ReviewAt(base.Card, Good, start.Add(10*time.Minute))
+	forwarded, _ := scheduler.ReviewAt(synced.Card, Good, start.Add(3*dayDuration))
+
+	if Conflicts(base.Card, forwarded.Card) || Conflicts(forwarded.Card, base.Card) {
+		t.Errorf("Expected a fast-forward not to conflict")
+	}
+	merged, err := MergeCards(base.Card, forwarded.Card)
+	if err != nil || merged != forwarded.Card {
+		t.Errorf("Expected the merge to fast-forward, but got %+v, %v", merged, err)
+	}
+	if Conflicts(synced.Card, synced.Card) {
+		t.Errorf("Expected identical versions not to conflict")
+	}
+
+	deviceA, _ := scheduler.ReviewAt(synced.Card, Good, start.Add(2*dayDuration))
+	deviceB, _ := scheduler.ReviewAt(synced.Card, Again, start.Add(2*dayDuration+time.Hour))
+	if !Conflicts(deviceA.Card, deviceB.Card) {
+		t.Errorf("Expected reviews on two devices from the same version to conflict")
+	}
+	merged, err = MergeCards(deviceA.Card, deviceB.Card)
+	if !errors.Is(err, ErrSyncConflict) || merged != deviceB.Card {
+		t.Errorf("Expected ErrSyncConflict with the later version, but got %+v, %v", merged, err)
+	}
+
+	simultaneous, _ := scheduler.ReviewAt(synced.Card, Hard, start.Add(2*dayDuration))
+	if !Conflicts(deviceA.Card, simultaneous.Card) {
+		t.Errorf("Expected different reviews at the same time to conflict")
+	}
+
+	if Conflicts(deviceA.Card, NewCard(2)) {
+		t.Errorf("Expected different cards not to conflict")
+	}
+	if _, err := MergeCards(deviceA.Card, NewCard(2)); err == nil {
+		t.Errorf("Expected an error merging different cards")
+	}
+}
+
+func TestConflictsIgnoresTimeRepresentation(t *testing.T) {
+	scheduler := createDefaultScheduler()
+	result, _ := scheduler.ReviewAt(NewCard(1), Good, time.Now())
+	card := result.Card
+
+	data, err := json.Marshal(card)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	var roundTripped Card
+	if err := json.Unmarshal(data, &roundTripped); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	zoned := card
+	zoned.LastReview = card.LastReview.In(time.FixedZone("UTC+9", 9*60*60))
+
+	for _, other := range []Card{roundTripped, zoned} {
+		if Conflicts(card, other) {
+			t.Errorf("Expected %+v not to conflict with %+v", other, card)
+		}
+		if _, err := MergeCards(card, other); err != nil {
+			t.Errorf("Expected an unchanged card to merge, but got %v", err)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package fsrs
+
+import "slices"
+
+const trendWindow = 10
+
+// CardTrend summarizes one card's history. A success is any rating but
+// Again and a lapse is Again in the Review state, as in LeechCount.
+// RecentLapseRate and DifficultySlope, the per-review change of
+// difficulty from a least-squares fit, cover the last 10 reviews.
+type CardTrend struct {
+	CurrentStreak   int
+	LongestStreak   int
+	RecentLapseRate float64
+	DifficultySlope float64
+}
+
+func CardTrends(logs []ReviewLog) CardTrend {
+	sorted := slices.DeleteFunc(slices.Clone(logs), func(log ReviewLog) bool {
+		return log.Kind != LogReview
+	})
+	slices.SortStableFunc(sorted, func(a, b ReviewLog) int {
+		return a.ReviewTime.Compare(b.ReviewTime)
+	})
+
+	var trend CardTrend
+	for _, log := range sorted {
+		if log.Rating == Again {
+			trend.CurrentStreak = 0
+			continue
+		}
+		trend.CurrentStreak++
+		trend.LongestStreak = max(trend.LongestStreak, trend.CurrentStreak)
+	}
+
+	recent := sorted[max(0, len(sorted)-trendWindow):]
+	lapses := 0
+	for _, log := range recent {
+		if log.State == Review && log.Rating == Again {
+			lapses++
+		}
+	}
+	if len(recent) > 0 {
+		trend.RecentLapseRate = float64(lapses) / float64(len(recent))
+	}
+	trend.DifficultySlope = difficultySlope(recent)
+	return trend
+}
+
+func difficultySlope(logs []ReviewLog) float64 {
+	n := float64(len(logs))
+	if n < 2 {
+		return 0
+	}
+
+	var sumX, sumY, sumXY, sumXX float64
+	for i, log := range logs {
+		x := float64(i)
+		sumX += x
+		sumY += log.Difficulty
+		sumXY += x * log.Difficulty
+		sumXX += x * x
+	}
+	return (n*sumXY - sumX*sumY) / (n*sumXX - sumX*sumX)
+}
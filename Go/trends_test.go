@@ -0,0 +1,58 @@
+package fsrs
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestCardTrends(t *testing.T) {
+	config := DefaultSchedulerConfig()
+	config.EnableFuzzing = false
+	scheduler, _ := NewScheduler(config, testRand)
+	start := time.Date(2025, 1, 1, 9, 0, 0, 0, time.UTC)
+
+	card := NewCard(1)
+	reviewTime := start
+	var logs []ReviewLog
+	for _, rating := range []Rating{Good, Good, Good, Good, Again, Good, Good, Again, Again, Good, Good, Good} {
+		result, _ := scheduler.Review(card, rating, card.Interval, WithReviewTime(reviewTime))
+		logs = append(logs, result.Log)
+		card = result.Card
+		reviewTime = reviewTime.Add(card.Interval)
+	}
+
+	trend := CardTrends(logs)
+	if trend.CurrentStreak != 3 {
+		t.Errorf("Expected current streak 3, but got %d", trend.CurrentStreak)
+	}
+	if trend.LongestStreak != 4 {
+		t.Errorf("Expected longest streak 4, but got %d", trend.LongestStreak)
+	}
+	if math.Abs(trend.RecentLapseRate-0.2) > 1e-9 {
+		t.Errorf("Expected recent lapse rate 0.2, but got %v", trend.RecentLapseRate)
+	}
+	if trend.DifficultySlope <= 0 {
+		t.Errorf("Expected difficulty to trend upwards after lapses, but got %v", trend.DifficultySlope)
+	}
+
+	reversed := make([]ReviewLog, len(logs))
+	for i, log := range logs {
+		reversed[len(logs)-1-i] = log
+	}
+	if CardTrends(reversed) != trend {
+		t.Errorf("Expected trends to be independent of input order")
+	}
+}
+
+func TestCardTrendsShortHistory(t *testing.T) {
+	if trend := CardTrends(nil); trend != (CardTrend{}) {
+		t.Errorf("Expected zero trend for no history, but got %+v", trend)
+	}
+
+	trend := CardTrends([]ReviewLog{{CardID: 1, Rating: Good, Difficulty: 5}})
+	expected := CardTrend{CurrentStreak: 1, LongestStreak: 1}
+	if trend != expected {
+		t.Errorf("Expected %+v, but got %+v", expected, trend)
+	}
+}
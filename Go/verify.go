@@ -0,0 +1,82 @@
+package fsrs
+
+import (
+	"strconv"
+	"time"
+)
+
+type ProbeResult struct {
+	CardID     int64
+	Rating     Rating
+	State      State
+	Step       int
+	Interval   time.Duration
+	Stability  float64
+	Difficulty float64
+}
+
+type Mismatch struct {
+	CardID int64
+	Rating Rating
+	Old    ProbeResult
+	New    ProbeResult
+}
+
+// GenerateProbes reviews every card with every probe rating on its due
+// date, without fuzzing. Stability and difficulty are rounded to 9
+// significant digits so results survive text round-trips unchanged.
+func GenerateProbes(cards []Card, scheduler *Scheduler, probes []Rating) []ProbeResult {
+	results := make([]ProbeResult, 0, len(cards)*len(probes))
+	for _, card := range cards {
+		for _, rating := range probes {
+			next := scheduler.previewCard(card, rating, card.Interval)
+			results = append(results, ProbeResult{
+				CardID:     card.CardID,
+				Rating:     rating,
+				State:      next.State,
+				Step:       next.Step,
+				Interval:   next.Interval,
+				Stability:  canonicalFloat(next.Stability),
+				Difficulty: canonicalFloat(next.Difficulty),
+			})
+		}
+	}
+	return results
+}
+
+// VerifyDeck regenerates the probes and reports every result that differs
+// from oldResults, in card then probe order. A probe missing from
+// oldResults is reported with a zero Old value.
+func VerifyDeck(cards []Card, probes []Rating, oldResults []ProbeResult, scheduler *Scheduler) []Mismatch {
+	type probeKey struct {
+		cardID int64
+		rating Rating
+	}
+
+	old := make(map[probeKey]ProbeResult, len(oldResults))
+	for _, result := range oldResults {
+		result.Stability = canonicalFloat(result.Stability)
+		result.Difficulty = canonicalFloat(result.Difficulty)
+		old[probeKey{result.CardID, result.Rating}] = result
+	}
+
+	var mismatches []Mismatch
+	for _, current := range GenerateProbes(cards, scheduler, probes) {
+		previous, ok := old[probeKey{current.CardID, current.Rating}]
+		if ok && previous == current {
+			continue
+		}
+		mismatches = append(mismatches, Mismatch{
+			CardID: current.CardID,
+			Rating: current.Rating,
+			Old:    previous,
+			New:    current,
+		})
+	}
+	return mismatches
+}
+
+func canonicalFloat(x float64) float64 {
+	canonical, _ := strconv.ParseFloat(strconv.FormatFloat(x, 'g', 9, 64), 64)
+	return canonical
+}
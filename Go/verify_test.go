@@ -0,0 +1,67 @@
+package fsrs
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestVerifyDeckWithUnchangedScheduler(t *testing.T) {
+	scheduler := createDefaultScheduler()
+	cards := []Card{
+		NewCard(1),
+		{CardID: 2, Interval: 10 * dayDuration, Stability: 10.123456789123, Difficulty: 5.987654321, State: Review},
+		{CardID: 3, Interval: 10 * time.Minute, Stability: 2.3065, Difficulty: 2.1181, State: Learning, Step: 1},
+	}
+	probes := Ratings()
+
+	results := GenerateProbes(cards, scheduler, probes)
+	if len(results) != len(cards)*len(probes) {
+		t.Fatalf("Expected %d results, but got %d", len(cards)*len(probes), len(results))
+	}
+
+	data, err := json.Marshal(results)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	var stored []ProbeResult
+	if err := json.Unmarshal(data, &stored); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if mismatches := VerifyDeck(cards, probes, stored, scheduler); len(mismatches) != 0 {
+		t.Errorf("Expected no mismatches, but got %+v", mismatches)
+	}
+}
+
+func TestVerifyDeckDetectsChanges(t *testing.T) {
+	scheduler := createDefaultScheduler()
+	cards := []Card{
+		{CardID: 1, Interval: 10 * dayDuration, Stability: 10, Difficulty: 5, State: Review},
+		{CardID: 2, Interval: 3 * dayDuration, Stability: 3, Difficulty: 7, State: Review},
+	}
+	probes := []Rating{Again, Good}
+	old := GenerateProbes(cards, scheduler, probes)
+
+	config := DefaultSchedulerConfig()
+	config.Parameters[10] += 0.2
+	upgraded, _ := NewScheduler(config, testRand)
+
+	mismatches := VerifyDeck(cards, probes, old, upgraded)
+	if len(mismatches) == 0 {
+		t.Fatalf("Expected mismatches after changing parameters")
+	}
+	for _, m := range mismatches {
+		if m.Old == m.New {
+			t.Errorf("Expected differing results for card %d rating %v", m.CardID, m.Rating)
+		}
+		if m.Old.CardID != m.CardID || m.New.CardID != m.CardID {
+			t.Errorf("Expected results for card %d, but got %+v", m.CardID, m)
+		}
+	}
+
+	missing := VerifyDeck(cards, probes, old[:1], scheduler)
+	if len(missing) != 3 {
+		t.Errorf("Expected 3 mismatches for missing probes, but got %d", len(missing))
+	}
+}
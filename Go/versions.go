@@ -0,0 +1,104 @@
+package fsrs
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+)
+
+type AlgorithmVersion int
+
+const (
+	UnknownVersion AlgorithmVersion = 0
+	FSRS45         AlgorithmVersion = 1
+	FSRS5          AlgorithmVersion = 2
+	FSRS6          AlgorithmVersion = 3
+)
+
+const defaultParametersTolerance = 1e-4
+
+func defaultParameters(version AlgorithmVersion) []float64 {
+	switch version {
+	case FSRS45:
+		return []float64{0.4872, 1.4003, 3.7145, 13.8206, 5.1618, 1.2298, 0.8975, 0.031, 1.6474, 0.1367, 1.0461,
+			2.1072, 0.0793, 0.3246, 1.587, 0.2272, 2.8755}
+	case FSRS5:
+		return []float64{0.40255, 1.18385, 3.173, 15.69105, 7.1949, 0.5345, 1.4604, 0.0046, 1.54575, 0.1192, 1.01925,
+			1.9395, 0.11, 0.29605, 2.2698, 0.2315, 2.9898, 0.51655, 0.6621}
+	case FSRS6:
+		return DefaultSchedulerConfig().Parameters
+	}
+	return nil
+}
+
+// IsDefaultParameters reports whether w matches, within 1e-4 per
+// parameter, the default parameters of a known algorithm version, and
+// which one.
+func IsDefaultParameters(w []float64) (bool, AlgorithmVersion) {
+	for _, version := range []AlgorithmVersion{FSRS45, FSRS5, FSRS6} {
+		defaults := defaultParameters(version)
+		if len(defaults) != len(w) {
+			continue
+		}
+		matches := true
+		for i := range w {
+			if math.Abs(w[i]-defaults[i]) > defaultParametersTolerance {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			return true, version
+		}
+	}
+	return false, UnknownVersion
+}
+
+// MigrateDefaults returns the target version's defaults when w is a known
+// default set, and w unchanged with false when it holds custom parameters.
+func MigrateDefaults(w []float64, target AlgorithmVersion) ([]float64, bool) {
+	targetDefaults := defaultParameters(target)
+	if targetDefaults == nil {
+		return w, false
+	}
+	if isDefault, _ := IsDefaultParameters(w); !isDefault {
+		return w, false
+	}
+	return slices.Clone(targetDefaults), true
+}
+
+var ErrParameterMismatch = errors.New("parameter sets differ in version")
+
+// BlendParameters returns (1-t)*old + t*fitted element-wise, for moving to
+// newly fitted weights gradually. t must lie in [0, 1] and both sets must
+// be valid and of the same version, that is, the same length, where an
+// empty set stands for the latest defaults. The blend of two sets within
+// the parameter bounds stays within them, and t = 0 and t = 1 return the
+// endpoints exactly.
+func BlendParameters(old, fitted []float64, t float64) ([]float64, error) {
+	if !(t >= 0 && t <= 1) {
+		return nil, fmt.Errorf("invalid blend fraction %v, must be between 0 and 1", t)
+	}
+	if len(old) == 0 {
+		old = defaultParameters(FSRS6)
+	}
+	if len(fitted) == 0 {
+		fitted = defaultParameters(FSRS6)
+	}
+	if _, err := checkAndFillParameters(old); err != nil {
+		return nil, err
+	}
+	if _, err := checkAndFillParameters(fitted); err != nil {
+		return nil, err
+	}
+	if len(old) != len(fitted) {
+		return nil, fmt.Errorf("%w: %d and %d parameters", ErrParameterMismatch, len(old), len(fitted))
+	}
+
+	blended := make([]float64, len(old))
+	for i := range old {
+		blended[i] = (1-t)*old[i] + t*fitted[i]
+	}
+	return blended, nil
+}
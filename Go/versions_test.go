@@ -0,0 +1,111 @@
+package fsrs
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestIsDefaultParameters(t *testing.T) {
+	for _, version := range []AlgorithmVersion{FSRS45, FSRS5, FSRS6} {
+		isDefault, actual := IsDefaultParameters(defaultParameters(version))
+		if !isDefault || actual != version {
+			t.Errorf("Expected defaults of version %v to be recognized, but got %v %v", version, isDefault, actual)
+		}
+
+		rounded := defaultParameters(version)
+		for i := range rounded {
+			rounded[i] = float64(float32(rounded[i]))
+		}
+		if isDefault, actual := IsDefaultParameters(rounded); !isDefault || actual != version {
+			t.Errorf("Expected float32-rounded defaults of version %v to be recognized, but got %v %v", version, isDefault, actual)
+		}
+
+		for i := range rounded {
+			nearMiss := defaultParameters(version)
+			nearMiss[i] += 0.001
+			if isDefault, _ := IsDefaultParameters(nearMiss); isDefault {
+				t.Errorf("Expected version %v with parameter %d shifted by 0.001 not to be a default", version, i)
+			}
+		}
+	}
+
+	if isDefault, version := IsDefaultParameters(nil); isDefault || version != UnknownVersion {
+		t.Errorf("Expected empty parameters not to be a default, but got %v %v", isDefault, version)
+	}
+}
+
+func TestMigrateDefaults(t *testing.T) {
+	migrated, ok := MigrateDefaults(defaultParameters(FSRS5), FSRS6)
+	if !ok || !reflect.DeepEqual(migrated, defaultParameters(FSRS6)) {
+		t.Errorf("Expected FSRS-5 defaults to migrate to FSRS-6 defaults, but got %v %v", migrated, ok)
+	}
+
+	custom := defaultParameters(FSRS5)
+	custom[3] = 12.0
+	migrated, ok = MigrateDefaults(custom, FSRS6)
+	if ok || !reflect.DeepEqual(migrated, custom) {
+		t.Errorf("Expected custom parameters to be left alone, but got %v %v", migrated, ok)
+	}
+
+	migrated, ok = MigrateDefaults(defaultParameters(FSRS6), UnknownVersion)
+	if ok || !reflect.DeepEqual(migrated, defaultParameters(FSRS6)) {
+		t.Errorf("Expected unknown target to be rejected, but got %v %v", migrated, ok)
+	}
+}
+
+func TestBlendParameters(t *testing.T) {
+	old := defaultParameters(FSRS6)
+	fitted := []float64{0.3, 1.1, 2.9, 9.4, 6.1, 0.7, 2.6, 0.004, 1.7, 0.2, 0.9,
+		1.6, 0.07, 0.3, 1.9, 0.5, 2.1, 0.4, 0.12, 0.08, 0.2}
+
+	for _, c := range []struct {
+		t        float64
+		expected []float64
+	}{{0, old}, {1, fitted}} {
+		blended, err := BlendParameters(old, fitted, c.t)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if !reflect.DeepEqual(c.expected, blended) {
+			t.Errorf("Expected %v at t=%v, but got %v", c.expected, c.t, blended)
+		}
+	}
+
+	for _, fraction := range []float64{0.25, 0.5, 0.75} {
+		blended, err := BlendParameters(old, fitted, fraction)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		config := DefaultSchedulerConfig()
+		config.Parameters = blended
+		if _, err := NewScheduler(config, testRand); err != nil {
+			t.Errorf("Expected blend at t=%v to be valid, but got %v", fraction, err)
+		}
+		for i := range blended {
+			low, high := min(old[i], fitted[i]), max(old[i], fitted[i])
+			if blended[i] < low || blended[i] > high {
+				t.Errorf("Expected parameter %d between %v and %v, but got %v", i, low, high, blended[i])
+			}
+		}
+	}
+}
+
+func TestBlendParametersValidation(t *testing.T) {
+	fsrs6 := defaultParameters(FSRS6)
+	if _, err := BlendParameters(fsrs6, defaultParameters(FSRS5), 0.5); !errors.Is(err, ErrParameterMismatch) {
+		t.Errorf("Expected ErrParameterMismatch, but got %v", err)
+	}
+	for _, fraction := range []float64{-0.1, 1.1, math.NaN()} {
+		if _, err := BlendParameters(fsrs6, fsrs6, fraction); err == nil {
+			t.Errorf("Expected an error for t=%v", fraction)
+		}
+	}
+	invalid := slices.Clone(fsrs6)
+	invalid[3] = math.Inf(1)
+	if _, err := BlendParameters(fsrs6, invalid, 0.5); err == nil {
+		t.Errorf("Expected an error for non-finite parameters")
+	}
+}
@@ -0,0 +1,113 @@
+package fsrs
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+var ErrBudgetExceeded = errors.New("time budget exceeded")
+
+const workloadHorizonDays = 365
+
+// RetentionForTimeBudget returns the highest retention between 0.70 and
+// 0.97, in steps of 0.01, whose simulated daily review time for the cards
+// in logs fits within minutesPerDay. The logs decide which cards are
+// simulated and where each one starts: its history is replayed to its
+// current state, stability and difficulty, so lapses in it show up as a
+// weaker memory. From there each card is simulated with on-time reviews
+// rated Good, the next Review interval recomputed for the retention being
+// tried, and a fraction 1 - retention of review-state reviews costing one
+// extra relearning review.
+func (s *Scheduler) RetentionForTimeBudget(logs []ReviewLog, minutesPerDay, perCardSeconds float64) (float64, error) {
+	cardIDs, grouped := logsByCard(logs)
+	if len(cardIDs) == 0 {
+		return 0, errors.New("no review history")
+	}
+
+	cards := make([]Card, len(cardIDs))
+	for i, cardID := range cardIDs {
+		card := NewCard(cardID)
+		for _, log := range grouped[cardID] {
+			card = s.previewCard(card, log.Rating, log.Elapsed)
+		}
+		cards[i] = card
+	}
+
+	for percent := 97; percent >= 70; percent-- {
+		retention := float64(percent) / 100.0
+		simulator := s.withRetention(retention)
+		reviews := 0.0
+		for _, card := range cards {
+			reviews += simulator.reviewsPerDay(card)
+		}
+		minutes := reviews * perCardSeconds / 60.0
+		if minutes <= minutesPerDay {
+			return retention, nil
+		}
+	}
+	return 0, fmt.Errorf("%w: %d cards need more than %.1f minutes per day even at 0.70 retention", ErrBudgetExceeded, len(cardIDs), minutesPerDay)
+}
+
+func (s *Scheduler) withRetention(retention float64) *Scheduler {
+	clone := *s
+	clone.config.DesiredRetention = retention
+	clone.config.RetentionByDifficulty = nil
+	clone.config.EnableFuzzing = false
+	return &clone
+}
+
+// reviewsPerDay simulates card from its next review over the workload
+// horizon. A Review card's interval is recomputed for the scheduler's
+// retention first, since it was scheduled for another one.
+func (s *Scheduler) reviewsPerDay(card Card) float64 {
+	horizon := workloadHorizonDays * dayDuration
+	if card.State == Review {
+		card.Interval = s.intervalForRetention(card.Stability, s.config.DesiredRetention)
+	}
+	elapsed := card.Interval
+	reviews := 0.0
+	for elapsed <= horizon {
+		if card.State == Review {
+			reviews += 2.0 - s.config.DesiredRetention
+		} else {
+			reviews++
+		}
+		card = s.previewCard(card, Good, card.Interval)
+		elapsed += card.Interval
+	}
+	return reviews / workloadHorizonDays
+}
+
+const steadyStateGrowth = 1.01
+
+// SteadyStateReviewsPerDay returns the long-run reviews per day, or
+// 1/avgIntervalDays, of a card rated rating at every on-time review,
+// averaged over MaximumInterval days. Once intervals grow by less than 1%
+// per review the rest of that span is filled with the last interval.
+// Learning and relearning steps are skipped, so Again settles at the
+// shortest review interval. It returns 0 for an invalid rating.
+func (s *Scheduler) SteadyStateReviewsPerDay(rating Rating) float64 {
+	if rating < Again || rating > Easy {
+		return 0
+	}
+	horizon := float64(s.config.MaximumInterval)
+
+	card := s.toReviewState(s.calculateInitialReviewedCard(NewCard(0), rating, 0))
+	elapsed, reviews := 0.0, 0.0
+	for {
+		intervalDays := card.Interval.Hours() / dayDuration.Hours()
+		if elapsed+intervalDays > horizon {
+			break
+		}
+		elapsed += intervalDays
+		reviews++
+		next := s.toReviewState(s.calculateInitialReviewedCard(card, rating, card.Interval))
+		if float64(next.Interval) < float64(card.Interval)*steadyStateGrowth {
+			reviews += math.Floor((horizon - elapsed) / (next.Interval.Hours() / dayDuration.Hours()))
+			break
+		}
+		card = next
+	}
+	return reviews / horizon
+}
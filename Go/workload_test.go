@@ -0,0 +1,92 @@
+package fsrs
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRetentionForTimeBudget(t *testing.T) {
+	scheduler := createDefaultScheduler()
+	start := time.Date(2025, 1, 1, 9, 0, 0, 0, time.UTC)
+	var logs []ReviewLog
+	for id := int64(1); id <= 2000; id++ {
+		logs = append(logs, ReviewLog{CardID: id, Rating: Good, ReviewTime: start})
+		logs = append(logs, ReviewLog{CardID: id, Rating: Good, ReviewTime: start.Add(dayDuration), Elapsed: dayDuration})
+	}
+
+	generous, err := scheduler.RetentionForTimeBudget(logs, 120, 8)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	tight, err := scheduler.RetentionForTimeBudget(logs, 2, 8)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if generous != 0.97 {
+		t.Errorf("Expected generous budget to allow 0.97, but got %v", generous)
+	}
+	if tight >= generous {
+		t.Errorf("Expected tight budget to lower retention, but got %v vs %v", tight, generous)
+	}
+
+	if _, err := scheduler.RetentionForTimeBudget(logs, 0.5, 8); !errors.Is(err, ErrBudgetExceeded) {
+		t.Errorf("Expected ErrBudgetExceeded, but got %v", err)
+	}
+	if _, err := scheduler.RetentionForTimeBudget(nil, 10, 8); err == nil {
+		t.Errorf("Expected error without history")
+	}
+}
+
+func TestRetentionForTimeBudgetUsesHistory(t *testing.T) {
+	scheduler := createDefaultScheduler()
+	start := time.Date(2025, 1, 1, 9, 0, 0, 0, time.UTC)
+	var fresh, mature []ReviewLog
+	for id := int64(1); id <= 2000; id++ {
+		fresh = append(fresh, ReviewLog{CardID: id, Rating: Good, ReviewTime: start})
+		fresh = append(fresh, ReviewLog{CardID: id, Rating: Good, ReviewTime: start.Add(dayDuration), Elapsed: dayDuration})
+
+		card := NewCard(id)
+		at := start
+		for range 8 {
+			mature = append(mature, ReviewLog{CardID: id, Rating: Good, ReviewTime: at, Elapsed: card.Interval})
+			card = scheduler.previewCard(card, Good, card.Interval)
+			at = at.Add(card.Interval)
+		}
+	}
+
+	freshRetention, err := scheduler.RetentionForTimeBudget(fresh, 2, 8)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	matureRetention, err := scheduler.RetentionForTimeBudget(mature, 2, 8)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if matureRetention <= freshRetention {
+		t.Errorf("Expected mature cards to afford more than %v, but got %v", freshRetention, matureRetention)
+	}
+}
+
+func TestSteadyStateReviewsPerDay(t *testing.T) {
+	scheduler := createDefaultScheduler()
+	good := scheduler.SteadyStateReviewsPerDay(Good)
+	if !(good > 0 && good < 0.01) {
+		t.Errorf("Expected a small positive rate for Good, but got %v", good)
+	}
+	if again := scheduler.SteadyStateReviewsPerDay(Again); again <= good {
+		t.Errorf("Expected Again to need more reviews than Good, but got %v and %v", again, good)
+	}
+
+	config := DefaultSchedulerConfig()
+	config.DesiredRetention = 0.8
+	lower, _ := NewScheduler(config, testRand)
+	if lowerGood := lower.SteadyStateReviewsPerDay(Good); lowerGood >= good {
+		t.Errorf("Expected a lower rate at 0.8 retention than %v, but got %v", good, lowerGood)
+	}
+
+	if invalid := scheduler.SteadyStateReviewsPerDay(Rating(0)); invalid != 0 {
+		t.Errorf("Expected 0 for an invalid rating, but got %v", invalid)
+	}
+}